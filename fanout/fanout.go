@@ -3,12 +3,14 @@ package fanout
 import (
 	xcontext "MagicWand/library/context"
 	"MagicWand/library/log"
+	"MagicWand/trace"
 	"context"
 	"errors"
 	"fmt"
 	"os"
 	"runtime"
 	"sync"
+	"time"
 )
 
 var (
@@ -88,6 +90,8 @@ func (c *Fanout) Close() error {
 type item struct {
 	f   func(c context.Context)
 	ctx context.Context
+	// enqueue time of item, for trace queue wait time.
+	enqueue time.Time
 }
 
 type fanout struct {
@@ -121,25 +125,36 @@ func (c *fanout) proc() {
 		if t == nil {
 			return
 		}
-		wrapFunc(t.f)(t.ctx)
+		wrapFunc(t.f, time.Since(t.enqueue))(t.ctx)
 	}
 }
 
-func wrapFunc(f func(c context.Context)) (res func(context.Context)) {
+func wrapFunc(f func(c context.Context), wait time.Duration) (res func(context.Context)) {
 	res = func(ctx context.Context) {
+		// the span forked by TraceHandler.
+		tr, traced := trace.FromContext(ctx)
+		if traced {
+			tr.SetTag(trace.TagInt64(_tagQueueWait, wait.Microseconds()))
+			tr.SetLog(trace.Log(trace.LogEvent, _eventDequeued), trace.Log(_logQueueWait, wait.String()))
+		}
+		start := time.Now()
 		defer func() {
 			if r := recover(); r != nil {
 				buf := make([]byte, 64*1024)
 				buf = buf[:runtime.Stack(buf, false)]
 				fmt.Fprintf(os.Stderr, "fanout: panic recovered: %s\n%s\n", r, buf)
 				log.Errorc(ctx, "panic in fanout proc, err: %s, stack: %s", r, buf)
+				if traced {
+					tr.SetTag(trace.TagBool(trace.TagError, true))
+					tr.SetLog(trace.Log(trace.LogEvent, "error"), trace.Log(trace.LogMessage, fmt.Sprint(r)), trace.Log(trace.LogStack, string(buf)))
+				}
+			}
+			if traced {
+				tr.SetLog(trace.Log(trace.LogEvent, _eventFinished), trace.Log(_logDuration, time.Since(start).String()))
+				tr.Finish(nil)
 			}
 		}()
 		f(ctx)
-		// TODO trace
-		//if tr, ok := trace.FromContext(ctx); ok {
-		//	tr.Finish(nil)
-		//}
 	}
 	return
 }
@@ -150,7 +165,7 @@ func (c *fanout) Do(ctx context.Context, f func(ctx context.Context)) (err error
 		return c.ctx.Err()
 	}
 	select {
-	case c.ch <- &item{f: f, ctx: xcontext.Detach(ctx)}:
+	case c.ch <- &item{f: f, ctx: xcontext.Detach(ctx), enqueue: time.Now()}:
 	default:
 		err = ErrFull
 		//_metricChanFullCount.Inc(c.name)
@@ -165,7 +180,7 @@ func (c *fanout) SyncDo(ctx context.Context, f func(ctx context.Context)) (err e
 		return c.ctx.Err()
 	}
 	select {
-	case c.ch <- &item{f: f, ctx: xcontext.Detach(ctx)}:
+	case c.ch <- &item{f: f, ctx: xcontext.Detach(ctx), enqueue: time.Now()}:
 	case <-ctx.Done():
 		err = ctx.Err()
 	}
@@ -1,7 +1,10 @@
 package fanout
 
 import (
+	"MagicWand/trace"
+	"MagicWand/trace/tracetest"
 	"context"
+	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -61,3 +64,93 @@ func TestFanout_SyncDo(t *testing.T) {
 		t.Fatal("expect run is false")
 	}
 }
+
+func TestFanout_TraceFinish(t *testing.T) {
+	r := tracetest.Install(t)
+	ca := New("cache", Worker(1), Buffer(1024))
+	defer ca.Close()
+	ctx := trace.NewContext(context.Background(), trace.New("root"))
+	ca.Do(ctx, func(c context.Context) {
+		panic("error")
+	})
+	time.Sleep(time.Millisecond * 50)
+	spans := r.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("expect 1 span finished, got %d", len(spans))
+	}
+	sp := spans[0]
+	if sp.OperationName() != "Fanout:Do" {
+		t.Fatalf("expect operation Fanout:Do, got %s", sp.OperationName())
+	}
+	if !tracetest.HasTag(sp, trace.TagError, true) {
+		t.Fatal("expect error tag be true")
+	}
+	if _, ok := tracetest.TagValue(sp, _tagQueueWait); !ok {
+		t.Fatal("expect queue wait tag")
+	}
+	var stack bool
+	for _, l := range sp.Logs() {
+		for _, f := range l.Fields {
+			if f.Key == trace.LogStack && f.Value != "" {
+				stack = true
+			}
+		}
+	}
+	if !stack {
+		t.Fatal("expect stack log")
+	}
+	if events := tracetest.LogEvents(sp); fmt.Sprint(events) != "[dequeued error finished]" {
+		t.Fatalf("expect events dequeued, error and finished, got %v", events)
+	}
+}
+
+func TestFanout_TraceTags(t *testing.T) {
+	r := tracetest.Install(t)
+	ca := New("cache", Worker(1), Buffer(1024))
+	root := trace.New("root")
+	ctx := trace.NewContext(context.Background(), root)
+	ca.Do(ctx, func(c context.Context) {})
+	ca.SyncDo(ctx, func(c context.Context) {})
+	ca.Close()
+	root.Finish(nil)
+
+	for _, name := range []string{"Fanout:Do", "Fanout:SyncDo"} {
+		sp, ok := r.FindSpan(name)
+		if !ok {
+			t.Fatalf("expect span %s finished", name)
+		}
+		if !tracetest.IsChildOf(sp, root) {
+			t.Fatalf("expect span %s be child of root", name)
+		}
+		if !tracetest.HasTag(sp, "fanout.name", "cache") {
+			t.Fatalf("expect span %s has tag fanout.name=cache", name)
+		}
+		if !tracetest.HasTag(sp, trace.TagComponent, "sync/pipeline/fanout") {
+			t.Fatalf("expect span %s has tag component=sync/pipeline/fanout", name)
+		}
+		if !tracetest.HasTag(sp, trace.TagSpanKind, "background") {
+			t.Fatalf("expect span %s has tag span.kind=background", name)
+		}
+	}
+	if _, ok := r.FindSpan("root"); !ok {
+		t.Fatal("expect root span finished")
+	}
+}
+
+func TestFanout_TraceBaggage(t *testing.T) {
+	tracetest.Install(t)
+	ca := New("cache", Worker(1), Buffer(1024))
+	root := trace.New("root").SetBaggageItem("tenant", "t1")
+	ctx, cancel := context.WithCancel(trace.NewContext(context.Background(), root))
+	cancel()
+	var tenant string
+	ca.Do(ctx, func(c context.Context) {
+		if tr, ok := trace.FromContext(c); ok {
+			tenant = tr.BaggageItem("tenant")
+		}
+	})
+	ca.Close()
+	if tenant != "t1" {
+		t.Fatalf("expect baggage tenant=t1 in detached context, got %q", tenant)
+	}
+}
@@ -1,9 +1,24 @@
 package fanout
 
-import "context"
+import (
+	"MagicWand/trace"
+	"context"
+)
 
 var _ FanoutHandler = &TraceHandler{}
 
+const (
+	// _tagQueueWait how long the task waits in channel, in microseconds.
+	_tagQueueWait = "fanout.queue_wait_us"
+
+	// span events logged by worker: the task is taken from channel, and
+	// the task finished, with queue wait time and execution time.
+	_eventDequeued = "dequeued"
+	_eventFinished = "finished"
+	_logQueueWait  = "queue_wait"
+	_logDuration   = "duration"
+)
+
 var traceTags = []trace.Tag{
 	{Key: trace.TagSpanKind, Value: "background"},
 	{Key: trace.TagComponent, Value: "sync/pipeline/fanout"},
@@ -19,21 +34,31 @@ func newTraceHandler(name string, _ *Options, h FanoutHandler) FanoutHandler {
 }
 
 func (t *TraceHandler) Do(ctx context.Context, f func(ctx context.Context)) (err error) {
-	if tr, ok := trace.FromContext(ctx); ok {
+	tr, ok := trace.FromContext(ctx)
+	if ok {
 		tr = tr.Fork("", "Fanout:Do").SetTag(traceTags...)
 		tr.SetTag(trace.String("fanout.name", t.name))
 		ctx = trace.NewContext(ctx, tr)
 	}
-	return t.FanoutHandler.Do(ctx, f)
+	// the span is finished by worker once the task is enqueued.
+	if err = t.FanoutHandler.Do(ctx, f); err != nil && ok {
+		tr.Finish(&err)
+	}
+	return
 }
 
 func (t *TraceHandler) SyncDo(ctx context.Context, f func(ctx context.Context)) (err error) {
-	if tr, ok := trace.FromContext(ctx); ok {
+	tr, ok := trace.FromContext(ctx)
+	if ok {
 		tr = tr.Fork("", "Fanout:SyncDo").SetTag(traceTags...)
 		tr.SetTag(trace.String("fanout.name", t.name))
 		ctx = trace.NewContext(ctx, tr)
 	}
-	return t.FanoutHandler.SyncDo(ctx, f)
+	// the span is finished by worker once the task is enqueued.
+	if err = t.FanoutHandler.SyncDo(ctx, f); err != nil && ok {
+		tr.Finish(&err)
+	}
+	return
 }
 
 func (t *TraceHandler) Close() (err error) {
@@ -0,0 +1,348 @@
+package conf
+
+import (
+	"MagicWand/library/conf/env"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// source kind.
+const (
+	SourceFile = "file"
+	SourceEnv  = "env"
+	SourceFlag = "flag"
+)
+
+// _envPrefix env variable CONF_SET_LOG__V=1 overrides key log.v.
+const _envPrefix = "CONF_SET_"
+
+// Source where a config value came from.
+type Source struct {
+	// Kind file, env or flag.
+	Kind string
+	// Name file path, env variable or flag value.
+	Name string
+}
+
+func (s Source) String() string {
+	return s.Kind + ":" + s.Name
+}
+
+var (
+	_dir       string
+	_overrides overrides
+)
+
+// RegisterFlags register -conf and -conf.set flags to fs, they are not
+// registered by default so that apps may use the names for their own.
+// Without the flags, Load reads dir from CONF_DIR env variable.
+func RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&_dir, "conf", os.Getenv("CONF_DIR"), "config files `dir`, or use CONF_DIR env variable.")
+	fs.Var(&_overrides, "conf.set", "override config value, repeatable, or use CONF_SET_KEY__SUB env variable, format: key.sub=value.")
+}
+
+// overrides flag value of key=value list.
+type overrides []string
+
+func (o *overrides) String() string {
+	return strings.Join(*o, ",")
+}
+
+func (o *overrides) Set(s string) error {
+	if !strings.Contains(s, "=") {
+		return fmt.Errorf("conf: invalid override %q, format: key.sub=value", s)
+	}
+	*o = append(*o, s)
+	return nil
+}
+
+type option struct {
+	dir       string
+	envPrefix string
+	environ   []string
+	overrides []string
+	interval  time.Duration
+}
+
+// Option load option.
+type Option func(*option)
+
+// Dir load config files from dir, default from -conf flag if registered, or CONF_DIR env variable.
+func Dir(dir string) Option {
+	return func(o *option) {
+		o.dir = dir
+	}
+}
+
+// EnvPrefix override config by env variables with prefix, default CONF_SET_,
+// "__" in variable name separates the keys, e.g. CONF_SET_LOG__V=1 overrides log.v.
+func EnvPrefix(prefix string) Option {
+	return func(o *option) {
+		o.envPrefix = prefix
+	}
+}
+
+// Overrides override config by key.sub=value list, default from -conf.set flag if registered.
+func Overrides(kvs ...string) Option {
+	return func(o *option) {
+		o.overrides = kvs
+	}
+}
+
+// Interval poll interval of Watcher, default 5s.
+func Interval(d time.Duration) Option {
+	return func(o *option) {
+		o.interval = d
+	}
+}
+
+// Config layered config values, from low to high priority: files in dir
+// ordered by name, env variables, flags.
+type Config struct {
+	values  map[string]interface{}
+	sources map[string]Source
+}
+
+// Load load config files of toml, yaml and json in dir, then apply env
+// variable and flag overrides. Files are merged into one tree, later file
+// overrides the same key of former one.
+// It returns the error of env.Check if deploy env is rejected in strict mode.
+func Load(opts ...Option) (*Config, error) {
+	if err := env.Check(); err != nil {
+		return nil, err
+	}
+	return load(newOption(opts))
+}
+
+func newOption(opts []Option) *option {
+	dir := _dir
+	if dir == "" {
+		dir = os.Getenv("CONF_DIR")
+	}
+	o := &option{
+		dir:       dir,
+		envPrefix: _envPrefix,
+		environ:   os.Environ(),
+		overrides: _overrides,
+		interval:  _defaultInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func load(o *option) (*Config, error) {
+	c := &Config{
+		values:  make(map[string]interface{}),
+		sources: make(map[string]Source),
+	}
+	if o.dir != "" {
+		if err := c.loadDir(o.dir); err != nil {
+			return nil, err
+		}
+	}
+	if o.envPrefix != "" {
+		for _, kv := range o.environ {
+			k, v, ok := strings.Cut(kv, "=")
+			if !ok || !strings.HasPrefix(k, o.envPrefix) || len(k) == len(o.envPrefix) {
+				continue
+			}
+			path := strings.Split(strings.ToLower(k[len(o.envPrefix):]), "__")
+			c.set(path, parseValue(v), Source{Kind: SourceEnv, Name: k})
+		}
+	}
+	for _, kv := range o.overrides {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("conf: invalid override %q, format: key.sub=value", kv)
+		}
+		c.set(strings.Split(k, "."), parseValue(v), Source{Kind: SourceFlag, Name: kv})
+	}
+	return c, nil
+}
+
+func (c *Config) loadDir(dir string) error {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("conf: read dir %s error: %w", dir, err)
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		if !f.IsDir() && decoder(f.Name()) != nil {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("conf: read file %s error: %w", path, err)
+		}
+		m := make(map[string]interface{})
+		if err = decoder(name)(data, &m); err != nil {
+			return fmt.Errorf("conf: decode file %s error: %w", path, err)
+		}
+		c.merge(nil, m, Source{Kind: SourceFile, Name: path})
+	}
+	return nil
+}
+
+// decoder return the decoder of file by extension, nil if not supported.
+func decoder(name string) func([]byte, interface{}) error {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".toml":
+		return toml.Unmarshal
+	case ".yaml", ".yml":
+		return yaml.Unmarshal
+	case ".json":
+		return json.Unmarshal
+	}
+	return nil
+}
+
+// parseValue parse override value as yaml scalar or flow, e.g. 1, true, [a, b],
+// use the raw string if failed.
+func parseValue(s string) interface{} {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil || v == nil {
+		return s
+	}
+	return v
+}
+
+// merge merge the leaves of m to path.
+func (c *Config) merge(path []string, m map[string]interface{}, src Source) {
+	for k, v := range m {
+		p := append(path[:len(path):len(path)], k)
+		if sub, ok := toMap(v); ok && len(sub) > 0 {
+			c.merge(p, sub, src)
+			continue
+		}
+		c.set(p, v, src)
+	}
+}
+
+// set set value to path, the existing keys match case-insensitively.
+func (c *Config) set(path []string, v interface{}, src Source) {
+	m := c.values
+	for i, k := range path {
+		key := lookupKey(m, k)
+		path[i] = key
+		if i == len(path)-1 {
+			m[key] = v
+			break
+		}
+		sub, ok := toMap(m[key])
+		if !ok {
+			sub = make(map[string]interface{})
+		}
+		m[key] = sub
+		m = sub
+	}
+	key := strings.Join(path, ".")
+	// the former value is replaced, so are the sources of its leaves.
+	for k := range c.sources {
+		if strings.HasPrefix(k, key+".") {
+			delete(c.sources, k)
+		}
+	}
+	for i := 1; i < len(path); i++ {
+		delete(c.sources, strings.Join(path[:i], "."))
+	}
+	c.sources[key] = src
+}
+
+// lookupKey return the existing key of m equals k case-insensitively, or k.
+func lookupKey(m map[string]interface{}, k string) string {
+	if _, ok := m[k]; ok {
+		return k
+	}
+	for key := range m {
+		if strings.EqualFold(key, k) {
+			return key
+		}
+	}
+	return k
+}
+
+func toMap(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[fmt.Sprint(k)] = val
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// Get return the value of key, e.g. log.module, empty key means the whole tree.
+func (c *Config) Get(key string) (interface{}, bool) {
+	var v interface{} = c.values
+	if key == "" {
+		return v, true
+	}
+	for _, k := range strings.Split(key, ".") {
+		m, ok := toMap(v)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[lookupKey(m, k)]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// Source return where the value of key came from, the source of the
+// nearest ancestor is returned if key is in a value of list or map.
+func (c *Config) Source(key string) (Source, bool) {
+	path := strings.Split(key, ".")
+	for i := len(path); i > 0; i-- {
+		for k, src := range c.sources {
+			if strings.EqualFold(k, strings.Join(path[:i], ".")) {
+				return src, true
+			}
+		}
+	}
+	return Source{}, false
+}
+
+// Sources return the sources of all leaf values.
+func (c *Config) Sources() map[string]Source {
+	srcs := make(map[string]Source, len(c.sources))
+	for k, src := range c.sources {
+		srcs[k] = src
+	}
+	return srcs
+}
+
+// String dump all leaf values with their sources, for debugging.
+func (c *Config) String() string {
+	keys := make([]string, 0, len(c.sources))
+	for k := range c.sources {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var buf bytes.Buffer
+	for _, k := range keys {
+		v, _ := c.Get(k)
+		fmt.Fprintf(&buf, "%s=%v (%s)\n", k, v, c.sources[k])
+	}
+	return buf.String()
+}
@@ -0,0 +1,124 @@
+package conf
+
+import (
+	"MagicWand/library/conf/env"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogConfig struct {
+	Dir            string
+	FileBufferSize int64
+	V              int32
+	Module         map[string]int32
+	Filter         []string
+	Timeout        time.Duration
+}
+
+func writeFile(t *testing.T, dir, name, data string) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	toml := writeFile(t, dir, "a.toml", `
+[log]
+dir = "/data/log"
+v = 1
+timeout = "1s"
+[log.module]
+"dao*" = 2
+`)
+	yaml := writeFile(t, dir, "b.yaml", "log:\n  v: 3\n  module:\n    service: 1\n")
+	writeFile(t, dir, "c.txt", "ignored")
+
+	c, err := Load(
+		Dir(dir),
+		EnvPrefix("TEST_CONF_"),
+		Overrides("log.filter=[password, token]", "log.Module.dao*=4"),
+		func(o *option) { o.environ = []string{"TEST_CONF_LOG__FILE_BUFFER_SIZE=1024", "OTHER=1"} },
+	)
+	assert.NoError(t, err)
+
+	var lc testLogConfig
+	assert.NoError(t, c.Decode("log", &lc))
+	assert.Equal(t, testLogConfig{
+		Dir:            "/data/log",
+		FileBufferSize: 1024,
+		V:              3,
+		Module:         map[string]int32{"dao*": 4, "service": 1},
+		Filter:         []string{"password", "token"},
+		Timeout:        time.Second,
+	}, lc)
+
+	for key, src := range map[string]Source{
+		"log.dir":              {Kind: SourceFile, Name: toml},
+		"log.v":                {Kind: SourceFile, Name: yaml},
+		"log.module.service":   {Kind: SourceFile, Name: yaml},
+		"log.module.dao*":      {Kind: SourceFlag, Name: "log.Module.dao*=4"},
+		"log.file_buffer_size": {Kind: SourceEnv, Name: "TEST_CONF_LOG__FILE_BUFFER_SIZE"},
+		"log.filter":           {Kind: SourceFlag, Name: "log.filter=[password, token]"},
+	} {
+		s, ok := c.Source(key)
+		assert.True(t, ok, key)
+		assert.Equal(t, src, s, key)
+	}
+	_, ok := c.Source("trace")
+	assert.False(t, ok)
+	assert.Contains(t, c.String(), "log.v=3 (file:"+yaml+")\n")
+}
+
+func TestLoadError(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.json", "{")
+	_, err := Load(Dir(dir))
+	assert.Error(t, err)
+
+	_, err = Load(Dir(t.TempDir()), Overrides("log.v"))
+	assert.Error(t, err)
+
+	c, err := Load(Dir(t.TempDir()), Overrides("log.v=1"))
+	assert.NoError(t, err)
+	var lc testLogConfig
+	assert.Error(t, c.Decode("trace", &lc))
+	assert.Error(t, c.Decode("log", lc))
+}
+
+func TestLoadStrict(t *testing.T) {
+	defer func(deployEnv string, strict bool) { env.DeployEnv, env.Strict = deployEnv, strict }(env.DeployEnv, env.Strict)
+	env.DeployEnv, env.Strict = env.DeployEnvAvalon, true
+	_, err := Load(Dir(t.TempDir()))
+	assert.ErrorIs(t, err, env.ErrDeprecatedDeployEnv)
+
+	env.Strict = false
+	_, err = Load(Dir(t.TempDir()))
+	assert.NoError(t, err)
+}
+
+func TestRegisterFlags(t *testing.T) {
+	assert.Nil(t, flag.CommandLine.Lookup("conf"))
+	defer func() { _dir, _overrides = "", nil }()
+
+	dir := t.TempDir()
+	writeFile(t, dir, "a.toml", "[log]\nv = 1\n")
+	fs := flag.NewFlagSet("conf", flag.ContinueOnError)
+	RegisterFlags(fs)
+	assert.NoError(t, fs.Parse([]string{"-conf", dir, "-conf.set", "log.dir=/data/log"}))
+	assert.Error(t, fs.Parse([]string{"-conf.set", "log.dir"}))
+
+	c, err := Load(EnvPrefix(""))
+	assert.NoError(t, err)
+	v, _ := c.Get("log.v")
+	assert.EqualValues(t, 1, v)
+	v, _ = c.Get("log.dir")
+	assert.Equal(t, "/data/log", v)
+}
@@ -0,0 +1,139 @@
+package conf
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	_durationType        = reflect.TypeOf(time.Duration(0))
+	_textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Decode decode the value of key into v, empty key means the whole tree.
+// Keys match struct fields by json tag or field name, case-insensitively
+// and ignoring "_" and "-", e.g. file_buffer_size matches FileBufferSize.
+// time.Duration accepts string like "1s".
+func (c *Config) Decode(key string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("conf: decode %s into non-pointer %T", key, v)
+	}
+	val, ok := c.Get(key)
+	if !ok {
+		return fmt.Errorf("conf: key %s not found", key)
+	}
+	val, err := normalize(val, rv.Type().Elem())
+	if err != nil {
+		return fmt.Errorf("conf: decode %s error: %w", key, err)
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Errorf("conf: decode %s error: %w", key, err)
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("conf: decode %s error: %w", key, err)
+	}
+	return nil
+}
+
+// normalize rename the keys of v to the json names of fields of t, so that
+// v can be decoded by json.
+func normalize(v interface{}, t reflect.Type) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(_textUnmarshalerType) {
+		return v, nil
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := toMap(v)
+		if !ok {
+			return v, nil
+		}
+		out := make(map[string]interface{}, len(m))
+		for k, val := range m {
+			f, name, ok := field(t, k)
+			if !ok {
+				continue
+			}
+			nv, err := normalize(val, f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			out[name] = nv
+		}
+		return out, nil
+	case reflect.Map:
+		m, ok := toMap(v)
+		if !ok {
+			return v, nil
+		}
+		out := make(map[string]interface{}, len(m))
+		for k, val := range m {
+			nv, err := normalize(val, t.Elem())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			out[k] = nv
+		}
+		return out, nil
+	case reflect.Slice, reflect.Array:
+		s, ok := v.([]interface{})
+		if !ok {
+			return v, nil
+		}
+		out := make([]interface{}, len(s))
+		for i, val := range s {
+			nv, err := normalize(val, t.Elem())
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out[i] = nv
+		}
+		return out, nil
+	}
+	if t == _durationType {
+		if s, ok := v.(string); ok {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return nil, err
+			}
+			return int64(d), nil
+		}
+	}
+	return v, nil
+}
+
+// field find the exported field of t matches key, return the field and its json name.
+func field(t reflect.Type, key string) (reflect.StructField, string, bool) {
+	key = simplify(key)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag := f.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if n, _, _ := strings.Cut(tag, ","); n != "" {
+				name = n
+			}
+		}
+		if simplify(name) == key {
+			return f, name, true
+		}
+	}
+	return reflect.StructField{}, "", false
+}
+
+func simplify(s string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(s))
+}
@@ -0,0 +1,104 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// deploy env errors.
+var (
+	ErrUnknownDeployEnv    = errors.New("env: unknown deploy env")
+	ErrDeprecatedDeployEnv = errors.New("env: deprecated deploy env")
+)
+
+// _deployEnvs valid deploy envs, false means deprecated.
+var _deployEnvs = map[string]bool{
+	DeployEnvDev:    true,
+	DeployEnvFat1:   true,
+	DeployEnvUat:    true,
+	DeployEnvOffice: true,
+	DeployEnvAvalon: false,
+	DeployEnvPre:    true,
+	DeployEnvProd:   true,
+}
+
+// Strict reject unknown or deprecated deploy env in Check.
+var Strict, _ = strconv.ParseBool(os.Getenv("DEPLOY_STRICT"))
+
+// IsDev return true if deploy env is dev or not set.
+func IsDev() bool {
+	return DeployEnv == "" || DeployEnv == DeployEnvDev
+}
+
+// IsPreRelease return true if deploy env is pre.
+func IsPreRelease() bool {
+	return DeployEnv == DeployEnvPre
+}
+
+// IsProd return true if deploy env is prod.
+func IsProd() bool {
+	return DeployEnv == DeployEnvProd
+}
+
+// Validate return error if deploy env is unknown or deprecated.
+func Validate() error {
+	valid, ok := _deployEnvs[DeployEnv]
+	if !ok {
+		return fmt.Errorf("%w %q, must be one of %s", ErrUnknownDeployEnv, DeployEnv, strings.Join(validDeployEnvs(), "/"))
+	}
+	if !valid {
+		return fmt.Errorf("%w %q, use one of %s instead", ErrDeprecatedDeployEnv, DeployEnv, strings.Join(validDeployEnvs(), "/"))
+	}
+	return nil
+}
+
+// Check validate deploy env in strict mode, should be called after flag parsed at startup.
+func Check() error {
+	if !Strict {
+		return nil
+	}
+	return Validate()
+}
+
+func validDeployEnvs() []string {
+	return []string{DeployEnvDev, DeployEnvFat1, DeployEnvUat, DeployEnvOffice, DeployEnvPre, DeployEnvProd}
+}
+
+// Snapshot all env values for diagnostics.
+type Snapshot struct {
+	Region         string `json:"region"`
+	Zone           string `json:"zone"`
+	Hostname       string `json:"hostname"`
+	IP             string `json:"ip"`
+	DeployEnv      string `json:"deploy_env"`
+	Color          string `json:"color"`
+	AppID          string `json:"app_id"`
+	DiscoveryAppID string `json:"discovery_app_id"`
+	DiscoveryZone  string `json:"discovery_zone"`
+	DiscoveryHost  string `json:"discovery_host"`
+	HTTPPort       string `json:"http_port"`
+	GORPCPort      string `json:"gorpc_port"`
+	GRPCPort       string `json:"grpc_port"`
+}
+
+// GetSnapshot return the current env values.
+func GetSnapshot() Snapshot {
+	return Snapshot{
+		Region:         Region,
+		Zone:           Zone,
+		Hostname:       Hostname,
+		IP:             IP,
+		DeployEnv:      DeployEnv,
+		Color:          Color,
+		AppID:          AppID,
+		DiscoveryAppID: DiscoveryAppID,
+		DiscoveryZone:  DiscoveryZone,
+		DiscoveryHost:  DiscoveryHost,
+		HTTPPort:       HTTPPort,
+		GORPCPort:      GORPCPort,
+		GRPCPort:       GRPCPort,
+	}
+}
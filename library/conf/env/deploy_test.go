@@ -0,0 +1,41 @@
+package env
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeployEnv(t *testing.T) {
+	defer func(deployEnv string, strict bool) { DeployEnv, Strict = deployEnv, strict }(DeployEnv, Strict)
+
+	DeployEnv = ""
+	assert.True(t, IsDev())
+	DeployEnv = DeployEnvPre
+	assert.True(t, IsPreRelease())
+	assert.False(t, IsProd())
+	DeployEnv = DeployEnvProd
+	assert.True(t, IsProd())
+	assert.NoError(t, Validate())
+
+	DeployEnv = DeployEnvAvalon
+	assert.True(t, errors.Is(Validate(), ErrDeprecatedDeployEnv))
+	DeployEnv = "production"
+	assert.True(t, errors.Is(Validate(), ErrUnknownDeployEnv))
+	assert.Contains(t, Validate().Error(), `"production"`)
+
+	Strict = false
+	assert.NoError(t, Check())
+	Strict = true
+	assert.Error(t, Check())
+}
+
+func TestGetSnapshot(t *testing.T) {
+	defer func(appID string) { AppID = appID }(AppID)
+	AppID = "main.arch.test"
+	s := GetSnapshot()
+	assert.Equal(t, "main.arch.test", s.AppID)
+	assert.Equal(t, DeployEnv, s.DeployEnv)
+	assert.Equal(t, Zone, s.Zone)
+}
@@ -1,6 +1,11 @@
 package env
 
-import "os"
+import (
+	"flag"
+	"os"
+	"strconv"
+	"time"
+)
 
 // deploy env.
 const (
@@ -63,3 +68,38 @@ var (
 	// GRPCPort app listen grpc port.
 	GRPCPort string
 )
+
+func init() {
+	var err error
+	if Hostname = os.Getenv("HOSTNAME"); Hostname == "" {
+		if Hostname, err = os.Hostname(); err != nil {
+			Hostname = strconv.Itoa(int(time.Now().UnixNano()))
+		}
+	}
+	addFlag(flag.CommandLine)
+}
+
+// addFlag init env from flag, or env variable, or default value.
+func addFlag(fs *flag.FlagSet) {
+	// env
+	fs.StringVar(&Region, "region", defaultString("REGION", _region), "avaliable region. or use REGION env variable, value: sh etc.")
+	fs.StringVar(&Zone, "zone", defaultString("ZONE", _zone), "avaliable zone. or use ZONE env variable, value: sh001/sh002 etc.")
+	fs.StringVar(&DeployEnv, "deploy.env", defaultString("DEPLOY_ENV", _deployEnv), "deploy env. or use DEPLOY_ENV env variable, value: dev/fat1/uat/pre/prod etc.")
+	fs.StringVar(&AppID, "appid", os.Getenv("APP_ID"), "appid is global unique application id, register by service tree. or use APP_ID env variable.")
+	fs.StringVar(&Color, "deploy.color", os.Getenv("DEPLOY_COLOR"), "deploy.color is the identification of different experimental group. or use DEPLOY_COLOR env variable.")
+	fs.BoolVar(&Strict, "deploy.strict", Strict, "reject unknown or deprecated deploy env in Check. or use DEPLOY_STRICT env variable.")
+	fs.StringVar(&DiscoveryAppID, "discovery.appid", os.Getenv("DISCOVERY_APP_ID"), "discovery app id. or use DISCOVERY_APP_ID env variable.")
+	fs.StringVar(&DiscoveryZone, "discovery.zone", os.Getenv("DISCOVERY_ZONE"), "discovery zone. or use DISCOVERY_ZONE env variable.")
+	fs.StringVar(&DiscoveryHost, "discovery.host", os.Getenv("DISCOVERY_HOST"), "discovery host. or use DISCOVERY_HOST env variable.")
+	// app
+	fs.StringVar(&HTTPPort, "http.port", defaultString("HTTP_PORT", _httpPort), "app listen http port, default: 8000. or use HTTP_PORT env variable.")
+	fs.StringVar(&GORPCPort, "gorpc.port", defaultString("GORPC_PORT", _gorpcPort), "app listen gorpc port, default: 8099. or use GORPC_PORT env variable.")
+	fs.StringVar(&GRPCPort, "grpc.port", defaultString("GRPC_PORT", _grpcPort), "app listen grpc port, default: 9000. or use GRPC_PORT env variable.")
+}
+
+func defaultString(env, value string) string {
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	return value
+}
@@ -0,0 +1,27 @@
+package env
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddFlag(t *testing.T) {
+	t.Setenv("ZONE", "sh002")
+	t.Setenv("APP_ID", "main.arch.test")
+	t.Setenv("HTTP_PORT", "")
+
+	fs := flag.NewFlagSet("env", flag.ContinueOnError)
+	addFlag(fs)
+	assert.Equal(t, _region, Region)
+	assert.Equal(t, "sh002", Zone)
+	assert.Equal(t, _deployEnv, DeployEnv)
+	assert.Equal(t, "main.arch.test", AppID)
+	assert.Equal(t, _httpPort, HTTPPort)
+
+	assert.NoError(t, fs.Parse([]string{"-deploy.env=uat", "-zone=sh001", "-http.port=8080"}))
+	assert.Equal(t, DeployEnvUat, DeployEnv)
+	assert.Equal(t, "sh001", Zone)
+	assert.Equal(t, "8080", HTTPPort)
+}
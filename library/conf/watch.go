@@ -0,0 +1,163 @@
+package conf
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const _defaultInterval = time.Second * 5
+
+type subscriber struct {
+	key string
+	typ reflect.Type
+	cur interface{}
+	fn  func(old, new interface{})
+}
+
+// Watcher poll the config files in dir, reload the config when any file
+// changed, and notify the subscribers whose decoded value changed.
+type Watcher struct {
+	opt    *option
+	stdlog *log.Logger
+
+	mu    sync.Mutex
+	conf  *Config
+	stamp string
+	subs  []*subscriber
+
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewWatcher load config and start polling the files.
+func NewWatcher(opts ...Option) (*Watcher, error) {
+	o := newOption(opts)
+	if o.interval <= 0 {
+		o.interval = _defaultInterval
+	}
+	c, err := load(o)
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		opt:    o,
+		stdlog: log.New(os.Stderr, "conf.Watcher ", log.LstdFlags),
+		conf:   c,
+		stamp:  stamp(o.dir),
+		done:   make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.daemon()
+	return w, nil
+}
+
+// Config return the current config.
+func (w *Watcher) Config() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conf
+}
+
+// Subscribe decode the value of key into v, and call fn with the old and
+// new value when it changes, the values are pointers of the same type as v.
+func (w *Watcher) Subscribe(key string, v interface{}, fn func(old, new interface{})) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.conf.Decode(key, v); err != nil {
+		return err
+	}
+	w.subs = append(w.subs, &subscriber{key: key, typ: reflect.TypeOf(v).Elem(), cur: v, fn: fn})
+	return nil
+}
+
+// Reload reload the config and notify subscribers, no matter the files changed or not.
+func (w *Watcher) Reload() error {
+	c, err := load(w.opt)
+	if err != nil {
+		return err
+	}
+	type change struct {
+		fn       func(old, new interface{})
+		old, new interface{}
+	}
+	var changes []change
+	w.mu.Lock()
+	w.conf = c
+	for _, s := range w.subs {
+		nv := reflect.New(s.typ).Interface()
+		if err = c.Decode(s.key, nv); err != nil {
+			w.stdlog.Printf("decode %s error(%v)", s.key, err)
+			continue
+		}
+		if reflect.DeepEqual(s.cur, nv) {
+			continue
+		}
+		changes = append(changes, change{fn: s.fn, old: s.cur, new: nv})
+		s.cur = nv
+	}
+	w.mu.Unlock()
+	for _, ch := range changes {
+		ch.fn(ch.old, ch.new)
+	}
+	return nil
+}
+
+func (w *Watcher) daemon() {
+	defer w.wg.Done()
+	tk := time.NewTicker(w.opt.interval)
+	defer tk.Stop()
+	for {
+		select {
+		case <-tk.C:
+			s := stamp(w.opt.dir)
+			if s == w.stamp {
+				continue
+			}
+			w.stamp = s
+			if err := w.Reload(); err != nil {
+				w.stdlog.Printf("reload error(%v)", err)
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// Close stop polling, it is safe to call more than once.
+func (w *Watcher) Close() error {
+	w.closeOnce.Do(func() { close(w.done) })
+	w.wg.Wait()
+	return nil
+}
+
+// stamp return the name, size and modify time of config files in dir.
+func stamp(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return err.Error()
+	}
+	var ss []string
+	for _, f := range files {
+		if f.IsDir() || decoder(f.Name()) == nil {
+			continue
+		}
+		fi, err := os.Stat(filepath.Join(dir, f.Name()))
+		if err != nil {
+			continue
+		}
+		ss = append(ss, fmt.Sprintf("%s:%d:%d", f.Name(), fi.Size(), fi.ModTime().UnixNano()))
+	}
+	sort.Strings(ss)
+	return strings.Join(ss, ",")
+}
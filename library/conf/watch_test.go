@@ -0,0 +1,56 @@
+package conf
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "log.toml", "[log]\nv = 1\n")
+	w, err := NewWatcher(Dir(dir), EnvPrefix(""), Overrides(), Interval(time.Millisecond*10))
+	assert.NoError(t, err)
+	defer w.Close()
+
+	var (
+		mu       sync.Mutex
+		old, new *testLogConfig
+		calls    int
+	)
+	lc := &testLogConfig{}
+	assert.NoError(t, w.Subscribe("log", lc, func(o, n interface{}) {
+		mu.Lock()
+		old, new = o.(*testLogConfig), n.(*testLogConfig)
+		calls++
+		mu.Unlock()
+	}))
+	assert.Equal(t, int32(1), lc.V)
+	assert.Error(t, w.Subscribe("trace", &testLogConfig{}, nil))
+
+	// unchanged value does not notify.
+	assert.NoError(t, w.Reload())
+	assert.Equal(t, 0, calls)
+
+	writeFile(t, dir, "log.toml", "[log]\nv = 2\n[log.module]\n\"dao*\" = 3\n")
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls == 1
+	}, time.Second, time.Millisecond*10)
+	mu.Lock()
+	assert.Equal(t, lc, old)
+	assert.Equal(t, &testLogConfig{V: 2, Module: map[string]int32{"dao*": 3}}, new)
+	mu.Unlock()
+	v, _ := w.Config().Get("log.module.dao*")
+	assert.EqualValues(t, 3, v)
+}
+
+func TestWatcherClose(t *testing.T) {
+	w, err := NewWatcher(Dir(t.TempDir()), EnvPrefix(""), Overrides(), Interval(time.Millisecond*10))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	assert.NotPanics(t, func() { assert.NoError(t, w.Close()) })
+}
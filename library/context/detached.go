@@ -5,7 +5,8 @@ import (
 	"time"
 )
 
-// Detached return no deadline and cancel context
+// Detached return no deadline and cancel context,
+// the values of ctx such as trace and its baggage are kept.
 func Detach(ctx context.Context) context.Context {
 	return detached{ctx: ctx}
 }
@@ -0,0 +1,183 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// adminState the V, Module and Level of live config.
+type adminState struct {
+	V        *int32           `json:"v,omitempty"`
+	Module   map[string]int32 `json:"module,omitempty"`
+	Level    *string          `json:"level,omitempty"`
+	TTL      string           `json:"ttl,omitempty"`
+	RevertAt *time.Time       `json:"revert_at,omitempty"`
+}
+
+// adminField field of config changed by admin.
+const (
+	_adminV      = "v"
+	_adminModule = "module"
+	_adminLevel  = "level"
+)
+
+// pendingRevert the value before the first unreverted change of a field,
+// and the value of the latest change.
+type pendingRevert struct {
+	origin, put interface{}
+}
+
+type admin struct {
+	mu       sync.Mutex
+	timer    *time.Timer
+	gen      int
+	pending  map[string]*pendingRevert
+	revertAt time.Time
+}
+
+// NewAdminHandler return a http handler to view and change the V, Module and
+// Level of the live log config, it should only listen on local address.
+//
+//	GET  return {"v":0,"module":{"dao*":2},"level":"INFO"}
+//	PUT  {"module":{"dao*":2},"level":"debug","ttl":"10m"} change the present
+//	     fields, and revert them after ttl if ttl set. A field is reverted only
+//	     if it still holds the value put, e.g. not changed by Reload meanwhile.
+//	     PUT without ttl makes the change of its fields permanent.
+func NewAdminHandler() http.Handler {
+	return &admin{pending: make(map[string]*pendingRevert)}
+}
+
+func (a *admin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if err := a.put(r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(a.state())
+}
+
+func (a *admin) state() *adminState {
+	cfg := c()
+	level := Level(_minLevel.Load()).String()
+	s := &adminState{V: &cfg.V, Module: cfg.Module, Level: &level}
+	a.mu.Lock()
+	if a.timer != nil {
+		s.RevertAt = &a.revertAt
+	}
+	a.mu.Unlock()
+	return s
+}
+
+func (a *admin) put(r *http.Request) (err error) {
+	s := new(adminState)
+	if err = json.NewDecoder(r.Body).Decode(s); err != nil {
+		return fmt.Errorf("log: invalid body: %v", err)
+	}
+	if s.V != nil && *s.V < 0 {
+		return fmt.Errorf("log: invalid v %d", *s.V)
+	}
+	if s.Level != nil {
+		if _, err = parseLevel(*s.Level); err != nil {
+			return
+		}
+	}
+	var ttl time.Duration
+	if s.TTL != "" {
+		if ttl, err = time.ParseDuration(s.TTL); err != nil || ttl <= 0 {
+			return fmt.Errorf("log: invalid ttl %q", s.TTL)
+		}
+	}
+	puts := make(map[string]interface{})
+	if s.V != nil {
+		puts[_adminV] = *s.V
+	}
+	if s.Module != nil {
+		puts[_adminModule] = s.Module
+	}
+	if s.Level != nil {
+		puts[_adminLevel] = *s.Level
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	update(func(c *Config) {
+		for k, v := range puts {
+			if ttl == 0 {
+				delete(a.pending, k)
+			} else if p, ok := a.pending[k]; ok {
+				p.put = v
+			} else {
+				a.pending[k] = &pendingRevert{origin: getField(c, k), put: v}
+			}
+			setField(c, k, v)
+		}
+	})
+	if ttl == 0 {
+		if len(a.pending) == 0 && a.timer != nil {
+			a.timer.Stop()
+			a.timer = nil
+			a.gen++
+		}
+		return
+	}
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+	a.gen++
+	gen := a.gen
+	a.revertAt = time.Now().Add(ttl)
+	a.timer = time.AfterFunc(ttl, func() { a.revert(gen) })
+	return
+}
+
+// revert revert the pending fields still holding the value put, if no
+// change with ttl since the timer of gen set.
+func (a *admin) revert(gen int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.gen != gen {
+		return
+	}
+	update(func(c *Config) {
+		for k, p := range a.pending {
+			if reflect.DeepEqual(getField(c, k), p.put) {
+				setField(c, k, p.origin)
+			}
+		}
+	})
+	a.pending = make(map[string]*pendingRevert)
+	a.timer = nil
+}
+
+func getField(c *Config, k string) interface{} {
+	switch k {
+	case _adminV:
+		return c.V
+	case _adminModule:
+		return c.Module
+	default:
+		return c.Level
+	}
+}
+
+func setField(c *Config, k string, v interface{}) {
+	switch k {
+	case _adminV:
+		c.V = v.(int32)
+	case _adminModule:
+		c.Module, _ = v.(map[string]int32)
+	default:
+		c.Level = v.(string)
+	}
+}
@@ -0,0 +1,77 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func doAdmin(t *testing.T, h http.Handler, method, body string) (int, *adminState) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, "/debug/log", strings.NewReader(body)))
+	s := new(adminState)
+	if w.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), s))
+	}
+	return w.Code, s
+}
+
+func TestAdminHandler(t *testing.T) {
+	setupTest(t, &Config{V: 1, Module: map[string]int32{"dao*": 1}})
+	h := NewAdminHandler()
+
+	code, s := doAdmin(t, h, http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int32(1), *s.V)
+	assert.Equal(t, map[string]int32{"dao*": 1}, s.Module)
+	assert.Equal(t, "INFO", *s.Level)
+	assert.Nil(t, s.RevertAt)
+
+	for _, body := range []string{`{"level":"nope"}`, `{"ttl":"soon"}`, `{"ttl":"-1s"}`, `{"v":-1}`, `{`} {
+		code, _ = doAdmin(t, h, http.MethodPut, body)
+		assert.Equal(t, http.StatusBadRequest, code, body)
+	}
+	code, _ = doAdmin(t, h, http.MethodPost, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, s = doAdmin(t, h, http.MethodPut, `{"v":2,"module":{"service":3},"level":"debug"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int32(2), *s.V)
+	assert.Equal(t, map[string]int32{"service": 3}, s.Module)
+	assert.Equal(t, "DEBUG", *s.Level)
+	assert.Equal(t, "debug", c().Level)
+}
+
+func TestAdminHandlerRevert(t *testing.T) {
+	setupTest(t, &Config{V: 1, Level: "warn"})
+	h := NewAdminHandler()
+
+	code, s := doAdmin(t, h, http.MethodPut, `{"v":3,"level":"debug","ttl":"50ms"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotNil(t, s.RevertAt)
+	// the second change extends ttl, and reverts to the value before the first.
+	doAdmin(t, h, http.MethodPut, `{"v":4,"module":{"dao*":2},"ttl":"100ms"}`)
+	// V changed by reload meanwhile is not reverted.
+	update(func(c *Config) { c.V = 5 })
+
+	time.Sleep(time.Millisecond * 70)
+	assert.Equal(t, "debug", c().Level)
+	assert.Eventually(t, func() bool { return c().Level == "warn" }, time.Second, time.Millisecond*10)
+	cfg := c()
+	assert.Equal(t, int32(5), cfg.V)
+	assert.Nil(t, cfg.Module)
+	assert.False(t, enabled(_infoLevel))
+	_, s = doAdmin(t, h, http.MethodGet, "")
+	assert.Nil(t, s.RevertAt)
+
+	// change without ttl is permanent.
+	doAdmin(t, h, http.MethodPut, `{"level":"error","ttl":"10ms"}`)
+	doAdmin(t, h, http.MethodPut, `{"level":"info"}`)
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, "info", c().Level)
+}
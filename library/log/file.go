@@ -58,14 +58,14 @@ func NewFile(dir string, bufferSize, rotateSize int64, maxLogFile int) *FileHand
 func (h *FileHandler) Log(ctx context.Context, lv Level, args ...D) {
 	d := toMap(args...)
 	// add extra fields
-	//addExtraField(ctx, d)
+	addExtraField(ctx, d)
 	d[_time] = time.Now().Format(_timeFormat)
 	d[_level] = levelNames[lv]
 	var w io.Writer
 	switch lv {
 	case _warnLevel:
 		w = h.fws[_warnIdx]
-	case _errorLevel:
+	case _errorLevel, _fatalLevel:
 		w = h.fws[_errorIdx]
 	default:
 		w = h.fws[_infoIdx]
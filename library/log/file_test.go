@@ -0,0 +1,34 @@
+package log
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileHandlerLevel(t *testing.T) {
+	dir := t.TempDir()
+	fh := NewFile(dir, 0, 0, 0)
+	fh.SetFormat("[%L] %M")
+	ctx := context.Background()
+	for _, lv := range []Level{_debugLevel, _infoLevel, _warnLevel, _errorLevel, _fatalLevel} {
+		fh.Log(ctx, lv, KVString(_log, lv.String()+"!"))
+	}
+	assert.NoError(t, fh.Close())
+
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		assert.NoError(t, err)
+		return string(b)
+	}
+	info, warn, errs := read("info.log"), read("warning.log"), read("error.log")
+	assert.Contains(t, info, "[DEBUG] DEBUG!")
+	assert.Contains(t, info, "[INFO] INFO!")
+	assert.NotContains(t, info, "FATAL")
+	assert.Contains(t, warn, "[WARN] WARN!")
+	assert.Contains(t, errs, "[ERROR] ERROR!")
+	assert.Contains(t, errs, "[FATAL] FATAL!")
+}
@@ -0,0 +1,224 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Config.Render values, case insensitive.
+const (
+	_renderPattern = "pattern"
+	_renderJSON    = "json"
+)
+
+// isJSONRender reports whether render name selects json render, unknown
+// names fall back to pattern with a warning.
+func isJSONRender(name string) bool {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case _renderJSON:
+		return true
+	case "", _renderPattern:
+		return false
+	}
+	log.Printf("log: unknown render %q, use pattern instead\n", name)
+	return false
+}
+
+// _jsonFirstKeys keys rendered first in order, the others follow sorted.
+var _jsonFirstKeys = []string{_time, _level, _source, _log}
+
+type jsonRender struct {
+	newline bool
+	bufPool sync.Pool
+}
+
+// newJSONRender new json render, renders one object per record, numbers,
+// bools as json types, durations as string like "1.5s".
+func newJSONRender(newline bool) Render {
+	return &jsonRender{
+		newline: newline,
+		bufPool: sync.Pool{New: func() interface{} { return &bytes.Buffer{} }},
+	}
+}
+
+// Render implement Formatter
+func (j *jsonRender) Render(w io.Writer, d map[string]interface{}) error {
+	buf := j.bufPool.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		j.bufPool.Put(buf)
+	}()
+	j.render(buf, d)
+	if j.newline {
+		buf.WriteByte('\n')
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// RenderString implement Formatter as string
+func (j *jsonRender) RenderString(d map[string]interface{}) string {
+	buf := j.bufPool.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		j.bufPool.Put(buf)
+	}()
+	j.render(buf, d)
+	return buf.String()
+}
+
+func (j *jsonRender) render(buf *bytes.Buffer, d map[string]interface{}) {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		if !isJSONFirstKey(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	buf.WriteByte('{')
+	first := true
+	writeKV := func(k string, v interface{}) {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		writeJSONString(buf, k)
+		buf.WriteByte(':')
+		writeJSONValue(buf, v)
+	}
+	for _, k := range _jsonFirstKeys {
+		if v, ok := d[k]; ok {
+			writeKV(k, v)
+		}
+	}
+	for _, k := range keys {
+		writeKV(k, d[k])
+	}
+	buf.WriteByte('}')
+}
+
+func isJSONFirstKey(k string) bool {
+	for _, fk := range _jsonFirstKeys {
+		if k == fk {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSONValue(buf *bytes.Buffer, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case string:
+		writeJSONString(buf, v)
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case int:
+		buf.WriteString(strconv.Itoa(v))
+	case int32:
+		buf.WriteString(strconv.FormatInt(int64(v), 10))
+	case int64:
+		buf.WriteString(strconv.FormatInt(v, 10))
+	case uint:
+		buf.WriteString(strconv.FormatUint(uint64(v), 10))
+	case uint32:
+		buf.WriteString(strconv.FormatUint(uint64(v), 10))
+	case uint64:
+		buf.WriteString(strconv.FormatUint(v, 10))
+	case float32:
+		writeJSONFloat(buf, float64(v), 32)
+	case float64:
+		writeJSONFloat(buf, v, 64)
+	case time.Duration:
+		writeJSONString(buf, v.String())
+	case time.Time:
+		writeJSONString(buf, v.Format(time.RFC3339Nano))
+	case error:
+		writeJSONString(buf, v.Error())
+	case fmt.Stringer:
+		writeJSONString(buf, v.String())
+	case json.Marshaler:
+		if b, err := v.MarshalJSON(); err == nil && json.Valid(b) {
+			buf.Write(b)
+			return
+		}
+		writeJSONString(buf, fmt.Sprint(v))
+	default:
+		writeJSONString(buf, fmt.Sprint(v))
+	}
+}
+
+// writeJSONFloat write float, NaN and Inf are not valid json numbers so quoted.
+func writeJSONFloat(buf *bytes.Buffer, f float64, bitSize int) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		writeJSONString(buf, strconv.FormatFloat(f, 'g', -1, bitSize))
+		return
+	}
+	buf.WriteString(strconv.FormatFloat(f, 'g', -1, bitSize))
+}
+
+const _hex = "0123456789abcdef"
+
+// writeJSONString write s quoted and escaped, invalid utf8 is replaced by U+FFFD.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			buf.WriteString(s[start:i])
+			switch c {
+			case '"', '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case '\n':
+				buf.WriteString(`\n`)
+			case '\r':
+				buf.WriteString(`\r`)
+			case '\t':
+				buf.WriteString(`\t`)
+			default:
+				buf.WriteString(`\u00`)
+				buf.WriteByte(_hex[c>>4])
+				buf.WriteByte(_hex[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf.WriteString(s[start:i])
+			buf.WriteString(`\ufffd`)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 and U+2029 break javascript parsers.
+		if r == '\u2028' || r == '\u2029' {
+			buf.WriteString(s[start:i])
+			buf.WriteString(`\u202`)
+			buf.WriteByte(_hex[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf.WriteString(s[start:])
+	buf.WriteByte('"')
+}
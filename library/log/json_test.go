@@ -0,0 +1,94 @@
+package log
+
+import (
+	"MagicWand/library/log/internal/core"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONRender(t *testing.T) {
+	d := toMap(
+		KVString(_log, "hello"),
+		KVString("b", "x"),
+		KVString(_time, "2024-01-02T15:04:05"),
+		KVString(_source, "a.go:1"),
+		KVString(_level, "INFO"),
+		KVInt("a", -1),
+		KVUint64("big", 1<<63+5),
+		KVFloat64("f", 1.5),
+		KVFloat32("f32", 0.25),
+		KVDuration("d", time.Millisecond*1500),
+		D{Key: "ok", Type: core.BoolType, StringVal: "true"},
+		KV("err", errors.New("boom")),
+		KV("nil", nil),
+	)
+	r := newJSONRender(false)
+	assert.Equal(t, `{"time":"2024-01-02T15:04:05","level":"INFO","source":"a.go:1","log":"hello",`+
+		`"a":-1,"b":"x","big":9223372036854775813,"d":"1.5s","err":"boom","f":1.5,"f32":0.25,"nil":null,"ok":true}`,
+		r.RenderString(d))
+}
+
+func TestJSONRenderEscape(t *testing.T) {
+	r := newJSONRender(false)
+	s := r.RenderString(map[string]interface{}{
+		"quote":   `a"b\c`,
+		"control": "a\nb\r\tc\x01",
+		"invalid": "a\xffb",
+		"line":    "a\u2028b\u2029",
+		"html":    "<a&b>",
+	})
+	assert.Equal(t, `{"control":"a\nb\r\tc\u0001","html":"<a&b>","invalid":"a\ufffdb","line":"a\u2028b\u2029","quote":"a\"b\\c"}`, s)
+	var m map[string]string
+	assert.NoError(t, json.Unmarshal([]byte(s), &m))
+	assert.Equal(t, "a\nb\r\tc\x01", m["control"])
+	assert.Equal(t, "a\ufffdb", m["invalid"])
+	assert.Equal(t, "a\u2028b\u2029", m["line"])
+}
+
+func TestJSONRenderFloat(t *testing.T) {
+	r := newJSONRender(false)
+	s := r.RenderString(map[string]interface{}{
+		"nan":  math.NaN(),
+		"inf":  math.Inf(1),
+		"ninf": float32(math.Inf(-1)),
+	})
+	assert.Equal(t, `{"inf":"+Inf","nan":"NaN","ninf":"-Inf"}`, s)
+	assert.True(t, json.Valid([]byte(s)))
+}
+
+func TestJSONRenderNewline(t *testing.T) {
+	d := map[string]interface{}{_log: "hello"}
+	var file, stdout bytes.Buffer
+	assert.NoError(t, newJSONRender(true).Render(&file, d))
+	assert.NoError(t, newJSONRender(false).Render(&stdout, d))
+	assert.Equal(t, "{\"log\":\"hello\"}\n", file.String())
+	assert.Equal(t, `{"log":"hello"}`, stdout.String())
+}
+
+func TestIsJSONRender(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	for name, want := range map[string]bool{
+		"":         false,
+		"pattern":  false,
+		"json":     true,
+		" JSON\n":  true,
+		"Pattern ": false,
+	} {
+		assert.Equal(t, want, isJSONRender(name), name)
+	}
+	assert.Empty(t, buf.String())
+
+	assert.False(t, isJSONRender("yaml"))
+	assert.Contains(t, buf.String(), `log: unknown render "yaml", use pattern instead`)
+}
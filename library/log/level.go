@@ -1,5 +1,10 @@
 package log
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Level of severity.
 type Level int
 
@@ -27,3 +32,13 @@ var levelNames = [...]string{
 func (l Level) String() string {
 	return levelNames[l]
 }
+
+// parseLevel parse level name case-insensitively, e.g. debug, INFO, warn.
+func parseLevel(s string) (Level, error) {
+	for lv, name := range levelNames {
+		if strings.EqualFold(name, s) || (Level(lv) == _warnLevel && strings.EqualFold(s, "warning")) {
+			return Level(lv), nil
+		}
+	}
+	return _infoLevel, fmt.Errorf("log: unknown level %q", s)
+}
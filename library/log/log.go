@@ -11,6 +11,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
 )
 
 // Config log config.
@@ -48,6 +49,12 @@ type Config struct {
 	Module map[string]int32
 	// Filter tell log handler which field are sensitive message, use * instead.
 	Filter []string
+	// Render render of stdout and file handler: pattern or json, default pattern.
+	// SetFormat switches the handlers back to pattern.
+	Render string
+	// Level minimum level: debug, info, warn, error, fatal, default info.
+	// The records below it are dropped before formatting.
+	Level string
 
 	ExtraResource map[string]interface{}
 }
@@ -70,6 +77,7 @@ var (
 )
 
 func init() {
+	_minLevel.Store(int32(_infoLevel))
 	host, _ := os.Hostname()
 	setGlobalCfg(&Config{
 		Family: env.AppID,
@@ -91,6 +99,9 @@ var (
 	//_noagent       bool
 	//_nootel        bool
 	_nostdout bool
+	_lvName   string
+	_render   string
+	_minLevel atomic.Int32
 
 	//_otelBatch           int
 	//_otelBuffer          int
@@ -134,6 +145,8 @@ func addFlag(fs *flag.FlagSet) {
 	//_noagent, _ = strconv.ParseBool(os.Getenv("LOG_NO_AGENT"))
 	//_nootel, _ = strconv.ParseBool(os.Getenv("LOG_NO_OTEL"))
 	_nostdout, _ = strconv.ParseBool(os.Getenv("LOG_NO_STDOUT"))
+	_lvName = os.Getenv("LOG_LEVEL")
+	_render = os.Getenv("LOG_RENDER")
 	//_otelLogFieldMaxSize, _ = strconv.Atoi(os.Getenv("OTEL_LOG_FIELD_MAX_SIZE"))
 	// get val from flag
 	fs.IntVar(&_v, "log.v", _v, "log verbose level, or use LOG_V env variable.")
@@ -141,6 +154,8 @@ func addFlag(fs *flag.FlagSet) {
 	fs.StringVar(&_dir, "log.dir", _dir, "log file `path, or use LOG_DIR env variable.")
 	//fs.StringVar(&_agentDSN, "log.agent", _agentDSN, "log agent dsn, or use LOG_AGENT env variable.")
 	//fs.StringVar(&_otelDSN, "log.otel", _otelDSN, "log otel dsn, or use LOG_OTEL env variable.")
+	fs.StringVar(&_lvName, "log.level", _lvName, "log minimum level: debug, info, warn, error, fatal, or use LOG_LEVEL env variable.")
+	fs.StringVar(&_render, "log.render", _render, "log render of stdout and file: pattern, json, or use LOG_RENDER env variable.")
 	fs.Var(&_module, "log.module", "log verbose for specified module, or use LOG_MODULE env variable, format: file=1,file2=2.")
 	fs.Var(&_extraResource, "log.extraResource", "log extraResource LOG_EXTRA_RESOURCE env variable, format: field1=1,file2=$env.")
 	fs.Var(&_filter, "log.filter", "log field for sensitive message, or use LOG_FILTER env variable, format: field1,field2.")
@@ -153,7 +168,8 @@ func addFlag(fs *flag.FlagSet) {
 	//fs.IntVar(&_otelLogFieldMaxSize, "log.otelLogFieldMaxSize", 0, "otel handler log field max size in bytes(truncate if exceed), 0 means no limit, default is 0")
 }
 
-// Init create logger with context.
+// Init create logger with context, it panics if deploy env is rejected
+// by env.Check in strict mode.
 func Init(conf *Config) {
 	_once.Do(func() {
 		_Init(conf)
@@ -162,6 +178,9 @@ func Init(conf *Config) {
 
 // Init create logger with context.
 func _Init(conf *Config) {
+	if err := env.Check(); err != nil {
+		panic(err)
+	}
 	var isNil bool
 
 	if conf == nil {
@@ -172,8 +191,11 @@ func _Init(conf *Config) {
 			V:      int32(_v),
 			Module: _module,
 			Filter: _filter,
+			Level:  _lvName,
+			Render: _render,
 		}
 	}
+	setMinLevel(conf.Level)
 
 	if len(conf.Host) == 0 {
 		if env.Hostname != "" {
@@ -184,17 +206,26 @@ func _Init(conf *Config) {
 		}
 	}
 	setGlobalCfg(conf)
+	jsonRender := isJSONRender(conf.Render)
 	var hs []Handler
 	// when env is dev
-	//if conf.Stdout || (isNil && (env.DeployEnv == "" || env.DeployEnv == env.DeployEnvDev)) || (_noagent && _nootel) {
-	if conf.Stdout || (isNil && (env.DeployEnv == "" || env.DeployEnv == env.DeployEnvDev)) {
+	//if conf.Stdout || (isNil && env.IsDev()) || (_noagent && _nootel) {
+	if conf.Stdout || (isNil && env.IsDev()) {
 		if !_nostdout {
-			hs = append(hs, NewStdout())
+			stdout := NewStdout()
+			if jsonRender {
+				stdout.render = newJSONRender(false)
+			}
+			hs = append(hs, stdout)
 			log.Printf("append stdout handler\n")
 		}
 	}
 	if conf.Dir != "" {
-		hs = append(hs, NewFile(conf.Dir, conf.FileBufferSize, conf.RotateSize, conf.MaxLogFile))
+		file := NewFile(conf.Dir, conf.FileBufferSize, conf.RotateSize, conf.MaxLogFile)
+		if jsonRender {
+			file.render = newJSONRender(true)
+		}
+		hs = append(hs, file)
 		log.Printf("append file handler\n")
 	}
 	//// enable otel for default
@@ -236,51 +267,120 @@ func _Init(conf *Config) {
 	SetGlobalHandler(newHandlers(conf.Filter, hs...))
 }
 
+// Debug logs a message at the debug log level.
+func Debug(format string, args ...interface{}) {
+	if !enabled(_debugLevel) {
+		return
+	}
+	h().Log(context.Background(), _debugLevel, KVString(_log, fmt.Sprintf(format, args...)))
+}
+
 // Info logs a message at the info log level.
 func Info(format string, args ...interface{}) {
+	if !enabled(_infoLevel) {
+		return
+	}
 	h().Log(context.Background(), _infoLevel, KVString(_log, fmt.Sprintf(format, args...)))
 }
 
 // Warn logs a message at the warning log level.
 func Warn(format string, args ...interface{}) {
+	if !enabled(_warnLevel) {
+		return
+	}
 	h().Log(context.Background(), _warnLevel, KVString(_log, fmt.Sprintf(format, args...)))
 }
 
 // Error logs a message at the error log level.
 func Error(format string, args ...interface{}) {
+	if !enabled(_errorLevel) {
+		return
+	}
 	h().Log(context.Background(), _errorLevel, KVString(_log, fmt.Sprintf(format, args...)))
 }
 
+// Fatal logs a message at the fatal log level, then flush all handlers and exit.
+func Fatal(format string, args ...interface{}) {
+	h().Log(context.Background(), _fatalLevel, KVString(_log, fmt.Sprintf(format, args...)))
+	exit()
+}
+
+// Debugc logs a message at the debug log level.
+func Debugc(ctx context.Context, format string, args ...interface{}) {
+	if !enabled(_debugLevel) {
+		return
+	}
+	h().Log(ctx, _debugLevel, KVString(_log, fmt.Sprintf(format, args...)))
+}
+
 // Infoc logs a message at the info log level.
 func Infoc(ctx context.Context, format string, args ...interface{}) {
+	if !enabled(_infoLevel) {
+		return
+	}
 	h().Log(ctx, _infoLevel, KVString(_log, fmt.Sprintf(format, args...)))
 }
 
 // Errorc logs a message at the error log level.
 func Errorc(ctx context.Context, format string, args ...interface{}) {
+	if !enabled(_errorLevel) {
+		return
+	}
 	h().Log(ctx, _errorLevel, KVString(_log, fmt.Sprintf(format, args...)))
 }
 
 // Warnc logs a message at the warning log level.
 func Warnc(ctx context.Context, format string, args ...interface{}) {
+	if !enabled(_warnLevel) {
+		return
+	}
 	h().Log(ctx, _warnLevel, KVString(_log, fmt.Sprintf(format, args...)))
 }
 
+// Fatalc logs a message at the fatal log level, then flush all handlers and exit.
+func Fatalc(ctx context.Context, format string, args ...interface{}) {
+	h().Log(ctx, _fatalLevel, KVString(_log, fmt.Sprintf(format, args...)))
+	exit()
+}
+
+// Debugv logs a message at the debug log level.
+func Debugv(ctx context.Context, args ...D) {
+	if !enabled(_debugLevel) {
+		return
+	}
+	h().Log(ctx, _debugLevel, args...)
+}
+
 // Infov logs a message at the info log level.
 func Infov(ctx context.Context, args ...D) {
+	if !enabled(_infoLevel) {
+		return
+	}
 	h().Log(ctx, _infoLevel, args...)
 }
 
 // Warnv logs a message at the warning log level.
 func Warnv(ctx context.Context, args ...D) {
+	if !enabled(_warnLevel) {
+		return
+	}
 	h().Log(ctx, _warnLevel, args...)
 }
 
 // Errorv logs a message at the error log level.
 func Errorv(ctx context.Context, args ...D) {
+	if !enabled(_errorLevel) {
+		return
+	}
 	h().Log(ctx, _errorLevel, args...)
 }
 
+// Fatalv logs a message at the fatal log level, then flush all handlers and exit.
+func Fatalv(ctx context.Context, args ...D) {
+	h().Log(ctx, _fatalLevel, args...)
+	exit()
+}
+
 func logw(args []interface{}) []D {
 	if len(args)%2 != 0 {
 		Warn("log: the variadic must be plural, the last one will ignored")
@@ -296,21 +396,45 @@ func logw(args []interface{}) []D {
 	return ds
 }
 
+// Debugw logs a message with some additional context. The variadic key-value pairs are treated as they are in With.
+func Debugw(ctx context.Context, args ...interface{}) {
+	if !enabled(_debugLevel) {
+		return
+	}
+	h().Log(ctx, _debugLevel, logw(args)...)
+}
+
 // Infow logs a message with some additional context. The variadic key-value pairs are treated as they are in With.
 func Infow(ctx context.Context, args ...interface{}) {
+	if !enabled(_infoLevel) {
+		return
+	}
 	h().Log(ctx, _infoLevel, logw(args)...)
 }
 
 // Warnw logs a message with some additional context. The variadic key-value pairs are treated as they are in With.
 func Warnw(ctx context.Context, args ...interface{}) {
+	if !enabled(_warnLevel) {
+		return
+	}
 	h().Log(ctx, _warnLevel, logw(args)...)
 }
 
 // Errorw logs a message with some additional context. The variadic key-value pairs are treated as they are in With.
 func Errorw(ctx context.Context, args ...interface{}) {
+	if !enabled(_errorLevel) {
+		return
+	}
 	h().Log(ctx, _errorLevel, logw(args)...)
 }
 
+// Fatalw logs a message with some additional context, then flush all handlers and exit.
+// The variadic key-value pairs are treated as they are in With.
+func Fatalw(ctx context.Context, args ...interface{}) {
+	h().Log(ctx, _fatalLevel, logw(args)...)
+	exit()
+}
+
 // SetFormat only effective on stdout and file handler
 // %T time format at "15:04:05.999" on stdout handler, "15:04:05 MST" on file handler
 // %t time format at "15:04:05" on stdout handler, "15:04" on file on file handler
@@ -352,6 +476,29 @@ func _Close() (err error) {
 //	}
 //}
 
+// exit flush all handlers and exit.
+func exit() {
+	h().Close()
+	os.Exit(1)
+}
+
+// enabled return true if lv is not below the minimum level.
+func enabled(lv Level) bool {
+	return int32(lv) >= _minLevel.Load()
+}
+
+// setMinLevel set the minimum level by name, info if empty or invalid.
+func setMinLevel(name string) {
+	lv := _infoLevel
+	if name != "" {
+		var err error
+		if lv, err = parseLevel(name); err != nil {
+			log.Printf("%v, use INFO instead\n", err)
+		}
+	}
+	_minLevel.Store(int32(lv))
+}
+
 func h() (handler Handler) {
 	_mu.RLock()
 	handler = _h
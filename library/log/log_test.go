@@ -0,0 +1,81 @@
+package log
+
+import (
+	"MagicWand/library/conf/env"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type record struct {
+	lv Level
+	d  map[string]interface{}
+}
+
+// testHandler record the logs.
+type testHandler struct {
+	mu      sync.Mutex
+	records []record
+}
+
+func (h *testHandler) Log(_ context.Context, lv Level, args ...D) {
+	h.mu.Lock()
+	h.records = append(h.records, record{lv: lv, d: toMap(args...)})
+	h.mu.Unlock()
+}
+
+func (h *testHandler) SetFormat(string) {}
+
+func (h *testHandler) Close() error { return nil }
+
+// logs return the log messages.
+func (h *testHandler) logs() (ls []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, r := range h.records {
+		ls = append(ls, r.lv.String()+" "+r.d[_log].(string))
+	}
+	return
+}
+
+// setupTest set cfg and a test handler as global, restored when test ends.
+func setupTest(t *testing.T, cfg *Config) *testHandler {
+	oc, oh, olv := c(), h(), _minLevel.Load()
+	t.Cleanup(func() {
+		setGlobalCfg(oc)
+		SetGlobalHandler(oh)
+		_minLevel.Store(olv)
+	})
+	th := &testHandler{}
+	setGlobalCfg(cfg)
+	SetGlobalHandler(newHandlers(cfg.Filter, th))
+	setMinLevel(cfg.Level)
+	return th
+}
+
+func TestInitStrict(t *testing.T) {
+	defer func(deployEnv string, strict bool) { env.DeployEnv, env.Strict = deployEnv, strict }(env.DeployEnv, env.Strict)
+	env.DeployEnv, env.Strict = "production", true
+	assert.Panics(t, func() { _Init(&Config{}) })
+}
+
+func TestMinLevel(t *testing.T) {
+	th := setupTest(t, &Config{})
+	ctx := context.Background()
+	// default minimum level is info.
+	Debug("dropped")
+	Debugc(ctx, "dropped")
+	Debugv(ctx, KVString(_log, "dropped"))
+	Debugw(ctx, _log, "dropped")
+	Info("info")
+	assert.Equal(t, []string{"INFO info"}, th.logs())
+
+	Reload(&Config{Level: "debug"})
+	Debugc(ctx, "debug")
+	Reload(&Config{Level: "ERROR"})
+	Warn("dropped")
+	Errorw(ctx, _log, "error")
+	assert.Equal(t, []string{"INFO info", "DEBUG debug", "ERROR error"}, th.logs())
+}
@@ -0,0 +1,21 @@
+// Package logconf watch log config from conf, keeping the core log package
+// free of the conf dependency.
+package logconf
+
+import (
+	"MagicWand/library/conf"
+	"MagicWand/library/log"
+)
+
+// Watch decode the log config of key from w, and re-apply V, Module,
+// Filter and Level live whenever it changes.
+func Watch(w *conf.Watcher, key string) error {
+	cfg := &log.Config{}
+	if err := w.Subscribe(key, cfg, func(_, n interface{}) {
+		log.Reload(n.(*log.Config))
+	}); err != nil {
+		return err
+	}
+	log.Reload(cfg)
+	return nil
+}
@@ -0,0 +1,56 @@
+package logconf
+
+import (
+	"MagicWand/library/conf"
+	"MagicWand/library/log"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testHandler struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (h *testHandler) Log(_ context.Context, lv log.Level, args ...log.D) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, d := range args {
+		if d.Key == "log" {
+			h.msgs = append(h.msgs, lv.String()+" "+d.StringVal)
+		}
+	}
+}
+
+func (h *testHandler) SetFormat(string) {}
+
+func (h *testHandler) Close() error { return nil }
+
+func TestWatch(t *testing.T) {
+	th := &testHandler{}
+	old := log.GetGlobalHandler()
+	log.SetGlobalHandler(th)
+	t.Cleanup(func() { log.SetGlobalHandler(old) })
+	log.Reload(&log.Config{Level: "debug"})
+	t.Cleanup(func() { log.Reload(&log.Config{Level: "info"}) })
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "log.toml"), []byte("[log]\nv = 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w, err := conf.NewWatcher(conf.Dir(dir), conf.EnvPrefix(""), conf.Overrides())
+	assert.NoError(t, err)
+	defer w.Close()
+
+	assert.NoError(t, Watch(w, "log"))
+	assert.True(t, bool(log.V(2)))
+	assert.False(t, bool(log.V(3)))
+	// level is kept as the watched config does not set it.
+	log.Debug("kept")
+	assert.Equal(t, []string{"DEBUG kept"}, th.msgs)
+}
@@ -0,0 +1,36 @@
+package log
+
+// Reload re-apply V, Module, Filter and Level of cfg to the live config and
+// handlers. Fields cfg does not set (zero V, nil Module or Filter, empty
+// Level) keep the current value, e.g. from -log.v flag; use an empty Module
+// or Filter to clear them.
+func Reload(cfg *Config) {
+	update(func(c *Config) {
+		if cfg.V != 0 {
+			c.V = cfg.V
+		}
+		if cfg.Module != nil {
+			c.Module = cfg.Module
+		}
+		if cfg.Filter != nil {
+			c.Filter = cfg.Filter
+		}
+		if cfg.Level != "" {
+			c.Level = cfg.Level
+		}
+	})
+}
+
+// update apply fn to a copy of the live config, then swap the config,
+// handlers and minimum level at once.
+func update(fn func(*Config)) {
+	_mu.Lock()
+	nc := *_c
+	fn(&nc)
+	_c = &nc
+	if hs, ok := _h.(*Handlers); ok {
+		_h = newHandlers(nc.Filter, hs.handlers...)
+	}
+	setMinLevel(nc.Level)
+	_mu.Unlock()
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReload(t *testing.T) {
+	th := setupTest(t, &Config{Level: "warn"})
+
+	Reload(&Config{V: 2, Filter: []string{"password"}})
+	assert.Equal(t, int32(2), c().V)
+	// level from flag is kept as the reloaded config does not set it.
+	assert.Equal(t, "warn", c().Level)
+	Info("dropped")
+	Warnw(context.Background(), _log, "kept", "password", "123")
+	assert.Equal(t, []string{"WARN kept"}, th.logs())
+	assert.Equal(t, "***", th.records[0].d["password"])
+
+	Reload(&Config{Level: "info"})
+	assert.Equal(t, "info", c().Level)
+}
+
+func TestReloadKeep(t *testing.T) {
+	setupTest(t, &Config{
+		V:      3,
+		Module: map[string]int32{"dao": 2},
+		Filter: []string{"password"},
+		Level:  "warn",
+	})
+
+	Reload(&Config{})
+	cfg := c()
+	assert.Equal(t, int32(3), cfg.V)
+	assert.Equal(t, map[string]int32{"dao": 2}, cfg.Module)
+	assert.Equal(t, []string{"password"}, cfg.Filter)
+	assert.Equal(t, "warn", cfg.Level)
+
+	Reload(&Config{V: 1})
+	assert.Equal(t, int32(1), c().V)
+	assert.Equal(t, map[string]int32{"dao": 2}, c().Module)
+
+	Reload(&Config{Module: map[string]int32{}})
+	assert.Empty(t, c().Module)
+	assert.Equal(t, []string{"password"}, c().Filter)
+
+	Reload(&Config{Filter: []string{}})
+	assert.Empty(t, c().Filter)
+	assert.Equal(t, "warn", c().Level)
+
+	Reload(&Config{Level: "error"})
+	assert.Equal(t, "error", c().Level)
+	assert.Equal(t, int32(1), c().V)
+}
@@ -0,0 +1,43 @@
+// Package tracefile report finished spans as json lines to rotating file,
+// for hosts without trace collector.
+package tracefile
+
+import (
+	"MagicWand/library/log/internal/filewriter"
+	"MagicWand/trace"
+)
+
+// Config trace file config.
+type Config struct {
+	// Path span file path, e.g. /data/log/app/trace.log
+	Path string
+	// RotateSize max size of single file, default 1GB.
+	RotateSize int64
+	// MaxFile max files keep, 0 meaning unlimit.
+	MaxFile int
+}
+
+// NewSender create a trace sender which writes spans to rotating file.
+func NewSender(c *Config) (trace.Sender, error) {
+	var options []filewriter.Option
+	if c.RotateSize > 0 {
+		options = append(options, filewriter.MaxSize(c.RotateSize))
+	}
+	if c.MaxFile > 0 {
+		options = append(options, filewriter.MaxFile(c.MaxFile))
+	}
+	w, err := filewriter.New(c.Path, options...)
+	if err != nil {
+		return nil, err
+	}
+	return trace.NewJSONSender(w), nil
+}
+
+// New create a async trace reporter which writes spans to rotating file.
+func New(c *Config, opts ...trace.ReporterOption) (*trace.AsyncReporter, error) {
+	sender, err := NewSender(c)
+	if err != nil {
+		return nil, err
+	}
+	return trace.NewAsyncReporter(sender, opts...), nil
+}
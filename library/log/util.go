@@ -1,7 +1,12 @@
 package log
 
 import (
+	"MagicWand/library/conf/env"
 	"MagicWand/library/log/internal/core"
+	"MagicWand/library/net/metadata"
+	"MagicWand/library/net/tenant"
+	"MagicWand/trace"
+	"context"
 	"math"
 	"runtime"
 	"strconv"
@@ -20,8 +25,10 @@ func toMap(args ...D) map[string]interface{} {
 	d := make(map[string]interface{}, 10+len(args))
 	for _, arg := range args {
 		switch arg.Type {
-		case core.UintType, core.Uint64Type, core.IntTpye, core.Int64Type:
+		case core.IntTpye, core.Int64Type:
 			d[arg.Key] = arg.Int64Val
+		case core.UintType, core.Uint64Type:
+			d[arg.Key] = uint64(arg.Int64Val)
 		case core.StringType:
 			d[arg.Key] = arg.StringVal
 		case core.Float32Type:
@@ -30,6 +37,8 @@ func toMap(args ...D) map[string]interface{} {
 			d[arg.Key] = math.Float64frombits(uint64(arg.Int64Val))
 		case core.DurationType:
 			d[arg.Key] = time.Duration(arg.Int64Val)
+		case core.BoolType:
+			d[arg.Key] = arg.StringVal == "true"
 		default:
 			d[arg.Key] = arg.Value
 		}
@@ -37,38 +46,37 @@ func toMap(args ...D) map[string]interface{} {
 	return d
 }
 
-//func addExtraField(ctx context.Context, fields map[string]interface{}) {
-//	if t, ok := trace.FromContext(ctx); ok {
-//		traceFlags := "00"
-//		if t.IsSampled() {
-//			traceFlags = "01"
-//		}
-//		fields[_tid] = t.TraceID()
-//		fields[_span] = t.SpanID()
-//		fields[_traceFlags] = traceFlags
-//	}
-//	if caller := metadata.String(ctx, metadata.Caller); caller != "" {
-//		fields[_caller] = caller
-//	}
-//	if color := metadata.String(ctx, metadata.Color); color != "" {
-//		fields[_color] = color
-//	}
-//	if env.Color != "" {
-//		fields[_envColor] = env.Color
-//	}
-//	if cluster := metadata.String(ctx, metadata.Cluster); cluster != "" {
-//		fields[_cluster] = cluster
-//	}
-//	fields[_deplyEnv] = env.DeployEnv
-//	fields[_zone] = env.Zone
-//	c := c()
-//	fields[_appID] = c.Family
-//	fields[_instanceID] = c.Host
-//	if mirror := metadata.String(ctx, metadata.Mirror); mirror != "" {
-//		fields[_mirror] = mirror
-//	}
-//	tenant, ok := tenant.FromContext(ctx)
-//	if ok {
-//		fields[_tenantKey] = tenant.TenantKey
-//	}
-//}
+func addExtraField(ctx context.Context, fields map[string]interface{}) {
+	if t, ok := trace.FromContext(ctx); ok {
+		traceFlags := "00"
+		if t.IsSampled() {
+			traceFlags = "01"
+		}
+		fields[_tid] = t.TraceID()
+		fields[_span] = t.SpanID()
+		fields[_traceFlags] = traceFlags
+	}
+	if caller := metadata.String(ctx, metadata.Caller); caller != "" {
+		fields[_caller] = caller
+	}
+	if color := metadata.String(ctx, metadata.Color); color != "" {
+		fields[_color] = color
+	}
+	if env.Color != "" {
+		fields[_envColor] = env.Color
+	}
+	if cluster := metadata.String(ctx, metadata.Cluster); cluster != "" {
+		fields[_cluster] = cluster
+	}
+	fields[_deplyEnv] = env.DeployEnv
+	fields[_zone] = env.Zone
+	c := c()
+	fields[_appID] = c.Family
+	fields[_instanceID] = c.Host
+	if mirror := metadata.String(ctx, metadata.Mirror); mirror != "" {
+		fields[_mirror] = mirror
+	}
+	if t, ok := tenant.FromContext(ctx); ok {
+		fields[_tenantKey] = t.TenantKey
+	}
+}
@@ -0,0 +1,100 @@
+package log
+
+import (
+	"MagicWand/library/conf/env"
+	"MagicWand/library/net/metadata"
+	"MagicWand/library/net/tenant"
+	"MagicWand/trace"
+	"MagicWand/trace/tracetest"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// logStdout log through a stdout handler with json render, return the decoded record.
+func logStdout(t *testing.T, ctx context.Context) map[string]interface{} {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	h := NewStdout()
+	h.render = newJSONRender(false)
+	h.Log(ctx, _infoLevel, KVString(_log, "hello"))
+	os.Stderr = stderr
+	w.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(b, &d))
+	return d
+}
+
+func TestTenantField(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), &tenant.Tenant{TenantKey: "acme"})
+	assert.Equal(t, "acme", logStdout(t, ctx)[_tenantKey])
+
+	_, ok := logStdout(t, context.Background())[_tenantKey]
+	assert.False(t, ok)
+	_, ok = logStdout(t, tenant.NewContext(context.Background(), nil))[_tenantKey]
+	assert.False(t, ok)
+}
+
+func TestExtraField(t *testing.T) {
+	defer func(deployEnv, zone, color string) {
+		env.DeployEnv, env.Zone, env.Color = deployEnv, zone, color
+	}(env.DeployEnv, env.Zone, env.Color)
+	env.DeployEnv, env.Zone, env.Color = env.DeployEnvUat, "sh002", "blue"
+	setupTest(t, &Config{Family: "main.arch.test", Host: "host-1"})
+	tracetest.Install(t)
+
+	tr := trace.New("root")
+	ctx := trace.NewContext(context.Background(), tr)
+	ctx = metadata.NewContext(ctx, metadata.MD{
+		metadata.Caller:  "main.arch.caller",
+		metadata.Color:   "red",
+		metadata.Cluster: "internal",
+		metadata.Mirror:  "1",
+	})
+	d := logStdout(t, ctx)
+	assert.NotEmpty(t, d[_time])
+	delete(d, _time)
+	assert.Equal(t, map[string]interface{}{
+		_log:        "hello",
+		_level:      "INFO",
+		_levelValue: float64(_infoLevel),
+		_tid:        tr.TraceID(),
+		_span:       tr.SpanID(),
+		_traceFlags: "01",
+		_caller:     "main.arch.caller",
+		_color:      "red",
+		_envColor:   "blue",
+		_cluster:    "internal",
+		_mirror:     "1",
+		_deplyEnv:   env.DeployEnvUat,
+		_zone:       "sh002",
+		_appID:      "main.arch.test",
+		_instanceID: "host-1",
+	}, d)
+
+	// context without trace and metadata carries the env fields only.
+	env.Color = ""
+	d = logStdout(t, context.Background())
+	delete(d, _time)
+	assert.Equal(t, map[string]interface{}{
+		_log:        "hello",
+		_level:      "INFO",
+		_levelValue: float64(_infoLevel),
+		_deplyEnv:   env.DeployEnvUat,
+		_zone:       "sh002",
+		_appID:      "main.arch.test",
+		_instanceID: "host-1",
+	}, d)
+}
@@ -0,0 +1,105 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// _noModule call site matches none of the module patterns.
+const _noModule int32 = -1
+
+// vCache caches the module level of call sites for a config, it is
+// dropped once the global config changed.
+type vCache struct {
+	cfg    *Config
+	levels sync.Map // pc -> int32
+}
+
+var _vcache atomic.Pointer[vCache]
+
+// V reports whether verbosity at the call site is at least the requested level.
+// The returned value is a boolean of type Verbose, which implements Info, Infov etc.
+// These methods will write to the Info log if called.
+// Thus, one may write either
+//
+//	if log.V(2) { log.Info("log this") }
+//
+// or
+//
+//	log.V(2).Info("log this")
+//
+// V-logs are info level, so V is always false if the minimum level is above info.
+// The level of call site is Config.V, or the level of Config.Module whose
+// pattern matches the caller's file name (minus the ".go" suffix) if greater.
+// A literal file name takes precedence over globs, and the highest level
+// wins if several globs match.
+func V(v int32) Verbose {
+	if v < 0 || !enabled(_infoLevel) {
+		return Verbose(false)
+	}
+	cfg := c()
+	if cfg.V >= v {
+		return Verbose(true)
+	}
+	if len(cfg.Module) == 0 {
+		return Verbose(false)
+	}
+	var pcs [1]uintptr
+	if runtime.Callers(2, pcs[:]) == 0 {
+		return Verbose(false)
+	}
+	vc := _vcache.Load()
+	if vc == nil || vc.cfg != cfg {
+		vc = &vCache{cfg: cfg}
+		_vcache.Store(vc)
+	}
+	lv, ok := vc.levels.Load(pcs[0])
+	if !ok {
+		lv = moduleLevel(cfg.Module, pcs[0])
+		vc.levels.Store(pcs[0], lv)
+	}
+	return Verbose(lv.(int32) >= v)
+}
+
+// moduleLevel return the level of module matches the file of pc. A literal
+// file name wins, otherwise the highest level of matching glob patterns.
+func moduleLevel(module map[string]int32, pc uintptr) int32 {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	file := strings.TrimSuffix(filepath.Base(frame.File), ".go")
+	if lv, ok := module[file]; ok {
+		return lv
+	}
+	lv := _noModule
+	for pattern, l := range module {
+		if ok, _ := filepath.Match(pattern, file); ok && l > lv {
+			lv = l
+		}
+	}
+	return lv
+}
+
+// Info logs a message at the info log level.
+func (v Verbose) Info(format string, args ...interface{}) {
+	if v {
+		h().Log(context.Background(), _infoLevel, KVString(_log, fmt.Sprintf(format, args...)))
+	}
+}
+
+// Infov logs a message at the info log level.
+func (v Verbose) Infov(ctx context.Context, args ...D) {
+	if v {
+		h().Log(ctx, _infoLevel, args...)
+	}
+}
+
+// Infow logs a message with some additional context. The variadic key-value pairs are treated as they are in With.
+func (v Verbose) Infow(ctx context.Context, args ...interface{}) {
+	if v {
+		h().Log(ctx, _infoLevel, logw(args)...)
+	}
+}
@@ -0,0 +1,58 @@
+package log
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleLevel(t *testing.T) {
+	var pcs [1]uintptr
+	runtime.Callers(1, pcs[:])
+	pc := pcs[0]
+	assert.Equal(t, int32(1), moduleLevel(map[string]int32{"verbose_test": 1, "verb*": 3}, pc))
+	assert.Equal(t, int32(3), moduleLevel(map[string]int32{"verbose_*": 2, "verb*": 3, "*": 1}, pc))
+	assert.Equal(t, _noModule, moduleLevel(map[string]int32{"dao*": 2}, pc))
+}
+
+func TestV(t *testing.T) {
+	setupTest(t, &Config{V: 1, Module: map[string]int32{"verbose_*": 3, "dao*": 5}})
+	assert.True(t, bool(V(1)))
+	assert.True(t, bool(V(3)))
+	assert.False(t, bool(V(4)))
+	assert.False(t, bool(V(-1)))
+
+	// decision of call site is cached until config changed.
+	v := func(lv int32) Verbose { return V(lv) }
+	assert.True(t, bool(v(3)))
+	vc := _vcache.Load()
+	assert.Same(t, c(), vc.cfg)
+	Reload(&Config{Module: map[string]int32{"verbose_*": 2}})
+	assert.False(t, bool(v(3)))
+	assert.True(t, bool(v(2)))
+	assert.NotSame(t, vc, _vcache.Load())
+
+	// V is kept as the reloaded config does not set it.
+	Reload(&Config{Module: map[string]int32{"dao*": 5}})
+	assert.True(t, bool(v(1)))
+	assert.False(t, bool(v(2)))
+}
+
+func TestVerbose(t *testing.T) {
+	th := setupTest(t, &Config{Module: map[string]int32{"verbose_*": 2}})
+	V(2).Info("info %d", 2)
+	V(2).Infov(context.Background(), KVString(_log, "infov"))
+	V(2).Infow(context.Background(), _log, "infow")
+	V(3).Info("dropped")
+	V(3).Infov(context.Background(), KVString(_log, "dropped"))
+	V(3).Infow(context.Background(), _log, "dropped")
+	assert.Equal(t, []string{"INFO info 2", "INFO infov", "INFO infow"}, th.logs())
+	assert.Contains(t, th.records[0].d[_source], "verbose_test.go:")
+
+	// V-logs are dropped when the minimum level is above info.
+	Reload(&Config{Module: map[string]int32{"verbose_*": 2}, Level: "warn"})
+	V(0).Info("dropped")
+	assert.Len(t, th.logs(), 3)
+}
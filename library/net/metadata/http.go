@@ -0,0 +1,85 @@
+package metadata
+
+import (
+	"MagicWand/library/conf/env"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// _httpHeaderPrefix metadata key color is propagated as http header X-Color.
+const _httpHeaderPrefix = "X-"
+
+// Allowlist keys of metadata which may cross the http boundary,
+// keys not in allowlist are neither injected nor extracted.
+type Allowlist map[string]struct{}
+
+// NewAllowlist new a allowlist with keys.
+func NewAllowlist(keys ...string) Allowlist {
+	a := make(Allowlist, len(keys))
+	for _, k := range keys {
+		a[k] = struct{}{}
+	}
+	return a
+}
+
+// Allowed return true if key may cross the boundary.
+func (a Allowlist) Allowed(key string) bool {
+	_, ok := a[key]
+	return ok
+}
+
+// DefaultAllowlist the outgoing keys and caller.
+var DefaultAllowlist = NewAllowlist(Color, Mirror, Criticality, Caller)
+
+// HTTPHeader return the http header name of metadata key, e.g. remote_ip -> X-Remote-Ip.
+func HTTPHeader(key string) string {
+	return http.CanonicalHeaderKey(_httpHeaderPrefix + strings.ReplaceAll(key, "_", "-"))
+}
+
+// InjectHTTP write the allowed metadata in ctx to http header.
+// env.Color is used if color not in ctx, and caller is always env.AppID.
+func InjectHTTP(ctx context.Context, header http.Header, allow Allowlist) {
+	md, _ := FromContext(ctx)
+	for k := range allow {
+		var val string
+		switch k {
+		case Caller:
+			val = env.AppID
+		case Color:
+			if val = String(ctx, Color); val == "" {
+				val = env.Color
+			}
+		default:
+			if v, ok := md[k]; ok && v != nil {
+				val = fmt.Sprint(v)
+			}
+		}
+		if val != "" {
+			header.Set(HTTPHeader(k), val)
+		}
+	}
+}
+
+// ExtractHTTP read the allowed metadata from http header, and return a
+// context with them joined to the metadata of ctx.
+// env.Color is used if color not in header.
+func ExtractHTTP(ctx context.Context, header http.Header, allow Allowlist) context.Context {
+	md := MD{}
+	for k := range allow {
+		if val := header.Get(HTTPHeader(k)); val != "" {
+			md[k] = val
+		}
+	}
+	if _, ok := md[Color]; !ok && allow.Allowed(Color) && env.Color != "" {
+		md[Color] = env.Color
+	}
+	if len(md) == 0 {
+		return ctx
+	}
+	if old, ok := FromContext(ctx); ok {
+		md = Join(old, md)
+	}
+	return NewContext(ctx, md)
+}
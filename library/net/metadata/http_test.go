@@ -0,0 +1,50 @@
+package metadata
+
+import (
+	"MagicWand/library/conf/env"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectHTTP(t *testing.T) {
+	defer func(appID, color string) { env.AppID, env.Color = appID, color }(env.AppID, env.Color)
+	env.AppID, env.Color = "main.arch.test", "blue"
+
+	ctx := NewContext(context.Background(), MD{Mirror: true, Cluster: "internal", Caller: "upstream"})
+	header := make(http.Header)
+	InjectHTTP(ctx, header, DefaultAllowlist)
+	assert.Equal(t, http.Header{
+		"X-Mirror": {"true"},
+		"X-Color":  {"blue"},
+		"X-Caller": {"main.arch.test"},
+	}, header)
+
+	header = make(http.Header)
+	InjectHTTP(NewContext(ctx, MD{Color: "red", RemoteIP: "127.0.0.1"}), header, NewAllowlist(Color, RemoteIP))
+	assert.Equal(t, http.Header{"X-Color": {"red"}, "X-Remote-Ip": {"127.0.0.1"}}, header)
+}
+
+func TestExtractHTTP(t *testing.T) {
+	defer func(color string) { env.Color = color }(env.Color)
+	env.Color = ""
+
+	header := http.Header{}
+	header.Set("X-Color", "red")
+	header.Set("X-Mirror", "1")
+	header.Set("X-Cluster", "internal")
+	ctx := NewContext(context.Background(), MD{Timeout: 100})
+	ctx = ExtractHTTP(ctx, header, DefaultAllowlist)
+	md, _ := FromContext(ctx)
+	assert.Equal(t, MD{Timeout: 100, Color: "red", Mirror: "1"}, md)
+	assert.True(t, Bool(ctx, Mirror))
+
+	env.Color = "blue"
+	ctx = ExtractHTTP(context.Background(), http.Header{}, DefaultAllowlist)
+	assert.Equal(t, "blue", String(ctx, Color))
+
+	ctx = context.Background()
+	assert.Equal(t, ctx, ExtractHTTP(ctx, header, NewAllowlist()))
+}
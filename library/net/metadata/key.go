@@ -0,0 +1,59 @@
+package metadata
+
+// metadata common key
+const (
+	// Network
+	RemoteIP   = "remote_ip"
+	RemotePort = "remote_port"
+	ServerAddr = "server_addr"
+	ClientAddr = "client_addr"
+
+	// Router
+	Cluster = "cluster"
+	Color   = "color"
+
+	// Trace
+	Trace  = "trace"
+	Caller = "caller"
+
+	// Timeout
+	Timeout = "timeout"
+
+	// Mirror
+	Mirror = "mirror"
+
+	// Device client device info.
+	Device = "device"
+
+	// Criticality criticality of request.
+	Criticality = "criticality"
+)
+
+// outgoingKey keys should propagate by rpc.
+var outgoingKey = map[string]struct{}{
+	Color:       {},
+	RemoteIP:    {},
+	RemotePort:  {},
+	Mirror:      {},
+	Criticality: {},
+}
+
+// incomingKey keys should extract from rpc metadata.
+var incomingKey = map[string]struct{}{
+	Caller: {},
+}
+
+// IsOutgoingKey represent this key should propagate by rpc.
+func IsOutgoingKey(key string) bool {
+	_, ok := outgoingKey[key]
+	return ok
+}
+
+// IsIncomingKey represent this key should extract from rpc metadata.
+func IsIncomingKey(key string) (ok bool) {
+	if _, ok = outgoingKey[key]; ok {
+		return
+	}
+	_, ok = incomingKey[key]
+	return
+}
@@ -0,0 +1,129 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// MD is a mapping from metadata keys to values.
+type MD map[string]interface{}
+
+type mdKey struct{}
+
+// Len returns the number of items in md.
+func (md MD) Len() int {
+	return len(md)
+}
+
+// Copy returns a copy of md.
+func (md MD) Copy() MD {
+	return Join(md)
+}
+
+// New creates an MD from a given key-value map.
+func New(m map[string]interface{}) MD {
+	md := MD{}
+	for k, val := range m {
+		md[k] = val
+	}
+	return md
+}
+
+// Join joins any number of mds into a single MD.
+// The value of a key is overwritten by the later md.
+func Join(mds ...MD) MD {
+	out := MD{}
+	for _, md := range mds {
+		for k, v := range md {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// Pairs returns an MD formed by the mapping of key, value ...
+// Pairs panics if len(kv) is odd.
+func Pairs(kv ...interface{}) MD {
+	if len(kv)%2 == 1 {
+		panic(fmt.Sprintf("metadata: Pairs got the odd number of input pairs for metadata: %d", len(kv)))
+	}
+	md := MD{}
+	var key string
+	for i, s := range kv {
+		if i%2 == 0 {
+			key = s.(string)
+			continue
+		}
+		md[key] = s
+	}
+	return md
+}
+
+// NewContext creates a new context with md attached.
+func NewContext(ctx context.Context, md MD) context.Context {
+	return context.WithValue(ctx, mdKey{}, md)
+}
+
+// FromContext returns the incoming metadata in ctx if it exists. The
+// returned MD should not be modified. Writing to it may cause races.
+// Modification should be made to the copies of the returned MD.
+func FromContext(ctx context.Context) (md MD, ok bool) {
+	md, ok = ctx.Value(mdKey{}).(MD)
+	return
+}
+
+// Value get value from metadata in context return nil if not found.
+func Value(ctx context.Context, key string) interface{} {
+	md, ok := ctx.Value(mdKey{}).(MD)
+	if !ok {
+		return nil
+	}
+	return md[key]
+}
+
+// String get string value from metadata in context.
+func String(ctx context.Context, key string) string {
+	md, ok := ctx.Value(mdKey{}).(MD)
+	if !ok {
+		return ""
+	}
+	str, _ := md[key].(string)
+	return str
+}
+
+// Int64 get int64 value from metadata in context, string value is parsed.
+func Int64(ctx context.Context, key string) int64 {
+	md, ok := ctx.Value(mdKey{}).(MD)
+	if !ok {
+		return 0
+	}
+	switch v := md[key].(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case string:
+		i, _ := strconv.ParseInt(v, 10, 64)
+		return i
+	}
+	return 0
+}
+
+// Bool get boolean from metadata in context, string value is parsed by strconv.ParseBool.
+func Bool(ctx context.Context, key string) bool {
+	md, ok := ctx.Value(mdKey{}).(MD)
+	if !ok {
+		return false
+	}
+	switch v := md[key].(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
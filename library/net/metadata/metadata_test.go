@@ -0,0 +1,49 @@
+package metadata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairsMD(t *testing.T) {
+	md := Pairs(Color, "red", Mirror, true)
+	assert.Equal(t, MD{Color: "red", Mirror: true}, md)
+	assert.Panics(t, func() { Pairs(Color) })
+}
+
+func TestJoin(t *testing.T) {
+	md := Join(MD{Color: "red", Caller: "a"}, MD{Color: "blue"})
+	assert.Equal(t, MD{Color: "blue", Caller: "a"}, md)
+	cp := md.Copy()
+	cp[Color] = "green"
+	assert.Equal(t, "blue", md[Color])
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	_, ok := FromContext(ctx)
+	assert.False(t, ok)
+	assert.Equal(t, "", String(ctx, Color))
+	assert.False(t, Bool(ctx, Mirror))
+	assert.Equal(t, int64(0), Int64(ctx, Timeout))
+
+	ctx = NewContext(ctx, MD{Color: "red", Mirror: "true", Timeout: "100", Caller: 1})
+	md, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, 4, md.Len())
+	assert.Equal(t, "red", String(ctx, Color))
+	assert.Equal(t, "", String(ctx, Caller))
+	assert.True(t, Bool(ctx, Mirror))
+	assert.Equal(t, int64(100), Int64(ctx, Timeout))
+	assert.Equal(t, 1, Value(ctx, Caller))
+}
+
+func TestKey(t *testing.T) {
+	assert.True(t, IsOutgoingKey(Color))
+	assert.False(t, IsOutgoingKey(Caller))
+	assert.True(t, IsIncomingKey(Caller))
+	assert.True(t, IsIncomingKey(Color))
+	assert.False(t, IsIncomingKey(Cluster))
+}
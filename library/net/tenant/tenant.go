@@ -0,0 +1,45 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+)
+
+// HTTPHeader http header carries the tenant key.
+const HTTPHeader = "X-Tenant-Key"
+
+// Tenant the customer a request belongs to in multi-tenant services.
+type Tenant struct {
+	// TenantKey unique key of tenant.
+	TenantKey string
+}
+
+type tenantKey struct{}
+
+// NewContext return a new context with tenant attached.
+func NewContext(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// FromContext return the tenant in ctx if it exists, a nil tenant is treated as not exists.
+func FromContext(ctx context.Context) (t *Tenant, ok bool) {
+	t, ok = ctx.Value(tenantKey{}).(*Tenant)
+	return t, ok && t != nil
+}
+
+// InjectHTTP write the tenant key in ctx to http header.
+func InjectHTTP(ctx context.Context, header http.Header) {
+	if t, ok := FromContext(ctx); ok && t.TenantKey != "" {
+		header.Set(HTTPHeader, t.TenantKey)
+	}
+}
+
+// ExtractHTTP read the tenant key from http header, return a context
+// with tenant attached, or ctx if header not found.
+func ExtractHTTP(ctx context.Context, header http.Header) context.Context {
+	key := header.Get(HTTPHeader)
+	if key == "" {
+		return ctx
+	}
+	return NewContext(ctx, &Tenant{TenantKey: key})
+}
@@ -0,0 +1,40 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := NewContext(context.Background(), &Tenant{TenantKey: "acme"})
+	tt, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tt.TenantKey)
+
+	_, ok = FromContext(NewContext(context.Background(), nil))
+	assert.False(t, ok)
+}
+
+func TestHTTP(t *testing.T) {
+	header := make(http.Header)
+	InjectHTTP(context.Background(), header)
+	assert.Empty(t, header)
+	InjectHTTP(NewContext(context.Background(), nil), header)
+	assert.Empty(t, header)
+
+	InjectHTTP(NewContext(context.Background(), &Tenant{TenantKey: "acme"}), header)
+	assert.Equal(t, "acme", header.Get(HTTPHeader))
+
+	tt, ok := FromContext(ExtractHTTP(context.Background(), header))
+	assert.True(t, ok)
+	assert.Equal(t, &Tenant{TenantKey: "acme"}, tt)
+
+	ctx := context.Background()
+	assert.Equal(t, ctx, ExtractHTTP(ctx, http.Header{}))
+}
@@ -0,0 +1,142 @@
+package trace
+
+import (
+	"fmt"
+	"strings"
+)
+
+// B3 headers, see https://github.com/openzipkin/b3-propagation
+const (
+	B3Single       = "b3"
+	B3TraceID      = "X-B3-TraceId"
+	B3SpanID       = "X-B3-SpanId"
+	B3ParentSpanID = "X-B3-ParentSpanId"
+	B3Sampled      = "X-B3-Sampled"
+	B3Flags        = "X-B3-Flags"
+)
+
+var (
+	_ propagator = b3Propagator{}
+	_ propagator = b3MultiPropagator{}
+)
+
+// b3Propagator propagate trace with single b3 header:
+// b3: {TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}
+type b3Propagator struct{}
+
+func (b3Propagator) Inject(sc spanContext, carrier Carrier) {
+	carrier.Set(B3Single, fmt.Sprintf("%s-%016x-%s", sc.traceIDString(), sc.SpanID, b3SamplingState(sc)))
+}
+
+func (b3Propagator) Extract(carrier Carrier) (sc spanContext, err error) {
+	b3 := strings.TrimSpace(carrier.Get(B3Single))
+	if b3 == "" {
+		return sc, ErrTraceNotFound
+	}
+	parts := strings.Split(b3, "-")
+	// a single sampling state is a valid b3 header which carries no trace.
+	if len(parts) < 2 {
+		return sc, ErrTraceNotFound
+	}
+	if len(parts) > 4 {
+		return sc, ErrTraceCorrupted
+	}
+	if sc.TraceIDHigh, sc.TraceID, err = parseB3TraceID(parts[0]); err != nil {
+		return sc, ErrTraceCorrupted
+	}
+	if sc.SpanID, err = parseB3SpanID(parts[1]); err != nil {
+		return sc, ErrTraceCorrupted
+	}
+	if len(parts) > 2 {
+		if sc.Flags, err = parseB3SamplingState(parts[2]); err != nil {
+			return sc, ErrTraceCorrupted
+		}
+	}
+	if !sc.IsValid() {
+		return sc, ErrTraceCorrupted
+	}
+	return sc, nil
+}
+
+// b3MultiPropagator propagate trace with multiple X-B3-* headers.
+type b3MultiPropagator struct{}
+
+func (b3MultiPropagator) Inject(sc spanContext, carrier Carrier) {
+	carrier.Set(B3TraceID, sc.traceIDString())
+	carrier.Set(B3SpanID, sc.spanIDString())
+	if sc.ParentID != 0 {
+		carrier.Set(B3ParentSpanID, fmt.Sprintf("%016x", sc.ParentID))
+	}
+	if sc.isMustKeep() {
+		carrier.Set(B3Flags, "1")
+	} else {
+		carrier.Set(B3Sampled, b3SamplingState(sc))
+	}
+}
+
+func (b3MultiPropagator) Extract(carrier Carrier) (sc spanContext, err error) {
+	traceID := carrier.Get(B3TraceID)
+	if traceID == "" {
+		return sc, ErrTraceNotFound
+	}
+	if sc.TraceIDHigh, sc.TraceID, err = parseB3TraceID(traceID); err != nil {
+		return sc, ErrTraceCorrupted
+	}
+	if sc.SpanID, err = parseB3SpanID(carrier.Get(B3SpanID)); err != nil {
+		return sc, ErrTraceCorrupted
+	}
+	if sampled := carrier.Get(B3Sampled); sampled != "" {
+		switch strings.ToLower(sampled) {
+		case "1", "true":
+			sc.Flags |= flagSampled
+		case "0", "false":
+		default:
+			return sc, ErrTraceCorrupted
+		}
+	}
+	if carrier.Get(B3Flags) == "1" {
+		sc.Flags |= flagSampled | flagDebug | flagMustKeep
+	}
+	if !sc.IsValid() {
+		return sc, ErrTraceCorrupted
+	}
+	return sc, nil
+}
+
+func b3SamplingState(sc spanContext) string {
+	switch {
+	case sc.isMustKeep():
+		return "d"
+	case sc.isSampled():
+		return "1"
+	}
+	return "0"
+}
+
+func parseB3SamplingState(s string) (int8, error) {
+	switch s {
+	case "d":
+		return flagSampled | flagDebug | flagMustKeep, nil
+	case "1":
+		return flagSampled, nil
+	case "0":
+		return 0, nil
+	}
+	return 0, fmt.Errorf("trace: invalid b3 sampling state %q", s)
+}
+
+// parseB3TraceID b3 trace id must be 16 or 32 hex characters.
+func parseB3TraceID(s string) (uint64, uint64, error) {
+	if len(s) != 16 && len(s) != 32 {
+		return 0, 0, fmt.Errorf("trace: invalid b3 trace id %q", s)
+	}
+	return parseTraceID(s)
+}
+
+// parseB3SpanID b3 span id must be 16 hex characters.
+func parseB3SpanID(s string) (uint64, error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("trace: invalid b3 span id %q", s)
+	}
+	return parseSpanID(s)
+}
@@ -0,0 +1,78 @@
+package trace
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// W3CBaggage w3c baggage header, see https://www.w3.org/TR/baggage/
+const W3CBaggage = "baggage"
+
+const (
+	_baggageMaxMembers = 180
+	_baggageMaxBytes   = 8192
+)
+
+// injectBaggage write baggage as w3c baggage header, members exceed
+// the limits of spec are dropped.
+func injectBaggage(baggage map[string]string, carrier Carrier) {
+	if len(baggage) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(baggage))
+	for k := range baggage {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var (
+		b strings.Builder
+		n int
+	)
+	for _, k := range keys {
+		if n >= _baggageMaxMembers {
+			break
+		}
+		member := url.PathEscape(k) + "=" + url.PathEscape(baggage[k])
+		if b.Len()+len(member)+1 > _baggageMaxBytes {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(member)
+		n++
+	}
+	carrier.Set(W3CBaggage, b.String())
+}
+
+// extractBaggage read w3c baggage header, invalid members are ignored.
+func extractBaggage(carrier Carrier) (baggage map[string]string) {
+	header := carrier.Get(W3CBaggage)
+	if header == "" {
+		return nil
+	}
+	for _, member := range strings.Split(header, ",") {
+		// properties after ";" are not supported.
+		if idx := strings.IndexByte(member, ';'); idx >= 0 {
+			member = member[:idx]
+		}
+		k, v, ok := strings.Cut(member, "=")
+		if !ok {
+			continue
+		}
+		key, err := url.PathUnescape(strings.TrimSpace(k))
+		if err != nil || key == "" {
+			continue
+		}
+		val, err := url.PathUnescape(strings.TrimSpace(v))
+		if err != nil {
+			continue
+		}
+		if baggage == nil {
+			baggage = make(map[string]string)
+		}
+		baggage[key] = val
+	}
+	return
+}
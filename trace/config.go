@@ -0,0 +1,85 @@
+package trace
+
+import (
+	"MagicWand/library/conf/env"
+)
+
+const (
+	// _defaultProbability sample one of 1024 traces by default.
+	_defaultProbability float32 = 1.0 / 1024
+)
+
+// Config config.
+type Config struct {
+	// ServiceName reported with every span, default env.AppID.
+	ServiceName string
+	// Probability probability sampling, default 1/1024.
+	Probability float32
+	// DisableSample sample every trace.
+	DisableSample bool
+	// Sampler decides whether a new trace should be sampled, use
+	// probability sampler with Probability if nil.
+	Sampler Sampler
+	// Reporter report sampled spans when finished, spans are dropped if nil.
+	Reporter Reporter
+	// TailSampling enable tail sampling in front of Reporter if not nil,
+	// every finished span is held by TailSampler, and the whole trace is
+	// reported if it is sampled, failed or slow.
+	TailSampling *TailSamplingConfig
+	// ValidateTags debug mode, report standard tags whose value type
+	// doesn't match the convention to stderr, see ValidateTag.
+	ValidateTags bool
+	// MaxTagsPerSpan max tags per span, default 128, negative meaning unlimit.
+	MaxTagsPerSpan int
+	// MaxLogsPerSpan max logs per span, default 256, negative meaning unlimit.
+	MaxLogsPerSpan int
+	// MaxValueLength max length of string tag value and log field value,
+	// longer value is truncated, default 4096, negative meaning unlimit.
+	MaxValueLength int
+	// MaxSpansPerTrace max spans of a trace in current process, spans
+	// over the limit are not reported, default 10000, negative meaning unlimit.
+	MaxSpansPerTrace int
+	// Propagators names of propagation format, default ["w3c"].
+	// Inject writes every format, Extract tries each format in turn.
+	// support: w3c, b3, b3multi, jaeger.
+	Propagators []string
+}
+
+func (c *Config) fix() {
+	if c.ServiceName == "" {
+		c.ServiceName = env.AppID
+	}
+	if c.Probability == 0 {
+		c.Probability = _defaultProbability
+	}
+	if c.Sampler == nil {
+		if c.DisableSample {
+			c.Sampler = AlwaysSample()
+		} else {
+			c.Sampler = NewProbabilitySampler(c.Probability)
+		}
+	}
+	if c.MaxTagsPerSpan == 0 {
+		c.MaxTagsPerSpan = _defaultMaxTagsPerSpan
+	}
+	if c.MaxLogsPerSpan == 0 {
+		c.MaxLogsPerSpan = _defaultMaxLogsPerSpan
+	}
+	if c.MaxValueLength == 0 {
+		c.MaxValueLength = _defaultMaxValueLength
+	}
+	if c.MaxSpansPerTrace == 0 {
+		c.MaxSpansPerTrace = _defaultMaxSpansPerTrace
+	}
+	if len(c.Propagators) == 0 {
+		c.Propagators = []string{PropagatorW3C}
+	}
+}
+
+// Init init trace report.
+func Init(cfg *Config) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	SetGlobalTracer(newTracer(cfg))
+}
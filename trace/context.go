@@ -0,0 +1,127 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const (
+	flagSampled = 0x01
+	flagDebug   = 0x02
+	// flagMustKeep the whole trace must be reported, span with this flag
+	// carries TagSamplingPriority with HighestSamplingPriority.
+	flagMustKeep = 0x04
+)
+
+type ctxKey string
+
+var _ctxkey ctxKey = "MagicWand/trace.trace"
+
+// spanContext implements opentracing.SpanContext
+type spanContext struct {
+	// TraceIDHigh represents the high 64 bits of a 128 bits trace id, zero for 64 bits trace id.
+	TraceIDHigh uint64
+
+	// TraceID represents globally unique ID of the trace.
+	// Usually generated as a random number.
+	TraceID uint64
+
+	// SpanID represents span ID that must be unique within its trace,
+	// but does not have to be globally unique.
+	SpanID uint64
+
+	// ParentID refers to the ID of the parent span.
+	// Should be 0 if the current span is a root span.
+	ParentID uint64
+
+	// Flags is a bitmap containing such bits as 'sampled' and 'debug'.
+	Flags int8
+
+	// TraceState is the vendor specific trace state received from upstream,
+	// see https://www.w3.org/TR/trace-context/#tracestate-header
+	TraceState string
+
+	// Baggage carries key:value pairs across process boundaries,
+	// copy on write, shared by parent and child spans.
+	Baggage map[string]string
+}
+
+// withBaggageItem return a copy of spanContext with baggage key set to val.
+func (c spanContext) withBaggageItem(key, val string) spanContext {
+	baggage := make(map[string]string, len(c.Baggage)+1)
+	for k, v := range c.Baggage {
+		baggage[k] = v
+	}
+	baggage[key] = val
+	c.Baggage = baggage
+	return c
+}
+
+func (c spanContext) isSampled() bool {
+	return (c.Flags & flagSampled) == flagSampled
+}
+
+func (c spanContext) isDebug() bool {
+	return (c.Flags & flagDebug) == flagDebug
+}
+
+func (c spanContext) isMustKeep() bool {
+	return (c.Flags & flagMustKeep) == flagMustKeep
+}
+
+// IsValid check spanContext valid
+func (c spanContext) IsValid() bool {
+	return (c.TraceIDHigh != 0 || c.TraceID != 0) && c.SpanID != 0
+}
+
+// traceIDString return 32 hex characters for 128 bits trace id, otherwise 16.
+func (c spanContext) traceIDString() string {
+	if c.TraceIDHigh != 0 {
+		return fmt.Sprintf("%016x%016x", c.TraceIDHigh, c.TraceID)
+	}
+	return fmt.Sprintf("%016x", c.TraceID)
+}
+
+func (c spanContext) spanIDString() string {
+	return fmt.Sprintf("%016x", c.SpanID)
+}
+
+func (c spanContext) String() string {
+	return fmt.Sprintf("%s:%016x:%016x:%d", c.traceIDString(), c.SpanID, c.ParentID, c.Flags)
+}
+
+// parseTraceID parse 16 or 32 hex characters trace id.
+func parseTraceID(s string) (high, low uint64, err error) {
+	switch {
+	case len(s) > 32 || len(s) == 0:
+		return 0, 0, fmt.Errorf("trace: invalid trace id %q", s)
+	case len(s) > 16:
+		if high, err = strconv.ParseUint(s[:len(s)-16], 16, 64); err != nil {
+			return
+		}
+		s = s[len(s)-16:]
+	}
+	low, err = strconv.ParseUint(s, 16, 64)
+	return
+}
+
+// parseSpanID parse at most 16 hex characters span id.
+func parseSpanID(s string) (uint64, error) {
+	if len(s) > 16 || len(s) == 0 {
+		return 0, fmt.Errorf("trace: invalid span id %q", s)
+	}
+	return strconv.ParseUint(s, 16, 64)
+}
+
+// NewContext new a trace context.
+// NOTE: This method is not thread safe.
+func NewContext(ctx context.Context, t Trace) context.Context {
+	return context.WithValue(ctx, _ctxkey, t)
+}
+
+// FromContext returns the trace bound to the context, if any.
+func FromContext(ctx context.Context) (t Trace, ok bool) {
+	t, ok = ctx.Value(_ctxkey).(Trace)
+	return
+}
@@ -0,0 +1,142 @@
+package trace
+
+import (
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+var (
+	_ Tracer    = &dapper{}
+	_ io.Closer = &dapper{}
+)
+
+type dapper struct {
+	serviceName string
+	sampler     Sampler
+	propagator  propagator
+	reporter    Reporter
+	stdlog      *log.Logger
+	limits      *spanLimits
+
+	validateTags bool
+}
+
+func newTracer(cfg *Config) *dapper {
+	cfg.fix()
+	p, err := newPropagator(cfg.Propagators...)
+	if err != nil {
+		panic(err)
+	}
+	reporter := cfg.Reporter
+	if reporter != nil && cfg.TailSampling != nil {
+		reporter = NewTailSampler(cfg.TailSampling, reporter)
+	}
+	return &dapper{
+		serviceName: cfg.ServiceName,
+		sampler:     cfg.Sampler,
+		propagator:  p,
+		reporter:    reporter,
+		stdlog:      log.New(os.Stderr, "trace ", log.LstdFlags),
+		limits:      newSpanLimits(cfg),
+
+		validateTags: cfg.ValidateTags,
+	}
+}
+
+// New trace instance with given operationName.
+func (d *dapper) New(operationName string, opts ...Option) Trace {
+	opt := defaultOption
+	for _, fn := range opts {
+		fn(&opt)
+	}
+	traceIDHigh, traceID := genID(), genID()
+	pctx := spanContext{TraceIDHigh: traceIDHigh, TraceID: traceID}
+	if opt.Debug {
+		pctx.Flags = flagSampled | flagDebug | flagMustKeep
+	} else if d.sampler.IsSampled(traceID, operationName) {
+		pctx.Flags = flagSampled
+	}
+	return d.newSpanWithContext(operationName, pctx, &traceLocal{}).SetTag(SpanKindServerTag)
+}
+
+func (d *dapper) newSpanWithContext(operationName string, pctx spanContext, local *traceLocal) *Span {
+	sp := &Span{dapper: d, local: local}
+	sp.operationName = operationName
+	sp.context = pctx
+	if pctx.SpanID != 0 {
+		sp.context.ParentID = pctx.SpanID
+	}
+	sp.context.SpanID = genID()
+	sp.startTime = time.Now()
+	sp.overLimit = !d.limits.allowSpan(local.spans.Add(1))
+	if sp.context.isMustKeep() {
+		local.mustKeep.Store(true)
+	}
+	if local.mustKeep.Load() {
+		sp.context.Flags |= flagSampled | flagMustKeep
+		sp.setTag(TagString(TagSamplingPriority, HighestSamplingPriority))
+	}
+	return sp
+}
+
+// Inject inject the span context of t into carrier.
+func (d *dapper) Inject(t Trace, format interface{}, carrier interface{}) error {
+	sp, ok := t.(*Span)
+	if !ok {
+		return ErrInvalidTrace
+	}
+	c, err := toCarrier(format, carrier)
+	if err != nil {
+		return err
+	}
+	sc := sp.spanContext()
+	d.propagator.Inject(sc, c)
+	injectBaggage(sc.Baggage, c)
+	return nil
+}
+
+// Extract extract a server span from carrier, use SetTitle to set the operation name.
+func (d *dapper) Extract(format interface{}, carrier interface{}) (Trace, error) {
+	c, err := toCarrier(format, carrier)
+	if err != nil {
+		return nil, err
+	}
+	pctx, err := d.propagator.Extract(c)
+	if err != nil {
+		return nil, err
+	}
+	pctx.Baggage = extractBaggage(c)
+	return d.newSpanWithContext("", pctx, &traceLocal{}).SetTag(SpanKindServerTag), nil
+}
+
+// report is called when a span finished.
+func (d *dapper) report(sp *Span) {
+	if sp.overLimit || d.reporter == nil {
+		return
+	}
+	// tail sampler decides by the whole trace.
+	if _, tail := d.reporter.(*TailSampler); !tail && !sp.IsSampled() {
+		return
+	}
+	if err := d.reporter.WriteSpan(sp); err != nil && err != ErrReporterFull && err != ErrReporterClosed {
+		d.stdlog.Printf("report span error: %s", err)
+	}
+}
+
+// LimitStats return the counts of data truncated or dropped by span limits.
+func (d *dapper) LimitStats() LimitStats {
+	return d.limits.stats()
+}
+
+// Close close the reporter and sampler.
+func (d *dapper) Close() (err error) {
+	if d.reporter != nil {
+		err = d.reporter.Close()
+	}
+	if e := d.sampler.Close(); e != nil {
+		err = e
+	}
+	return
+}
@@ -0,0 +1,75 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTracer() *dapper {
+	return newTracer(&Config{ServiceName: "test", DisableSample: true})
+}
+
+func TestDapperNew(t *testing.T) {
+	tracer := newTestTracer()
+	root := tracer.New("root").(*Span)
+	assert.True(t, root.IsSampled())
+	assert.Len(t, root.TraceID(), 32)
+	assert.Len(t, root.SpanID(), 16)
+	assert.Equal(t, "", root.ParentID())
+	assert.Equal(t, []Tag{SpanKindServerTag}, root.Tags())
+
+	child := root.Fork("peer", "child").(*Span)
+	assert.Equal(t, root.TraceID(), child.TraceID())
+	assert.Equal(t, root.SpanID(), child.ParentID())
+	assert.NotEqual(t, root.SpanID(), child.SpanID())
+	assert.Contains(t, child.Tags(), TagString(TagPeerService, "peer"))
+
+	follow := child.Follow("", "follow").(*Span)
+	assert.Equal(t, child.SpanID(), follow.ParentID())
+	assert.Contains(t, follow.Tags(), TagString(TagSpanKind, "producer"))
+}
+
+func TestSpanSetTag(t *testing.T) {
+	sp := newTestTracer().New("root")
+	sp.SetTag(TagString(TagSpanKind, "background"), TagString(TagComponent, "test"))
+	assert.Equal(t, []Tag{TagString(TagSpanKind, "background"), TagString(TagComponent, "test")}, sp.(*Span).Tags())
+}
+
+func TestSpanFinish(t *testing.T) {
+	sp := newTestTracer().New("root").(*Span)
+	err := errors.New("boom")
+	sp.Finish(&err)
+	assert.True(t, sp.Duration() > 0)
+	assert.Contains(t, sp.Tags(), TagBool(TagError, true))
+	assert.Len(t, sp.Logs(), 1)
+	assert.Equal(t, []LogField{Log(LogMessage, "boom")}, sp.Logs()[0].Fields)
+
+	d := sp.Duration()
+	sp.Finish(nil)
+	assert.Equal(t, d, sp.Duration())
+}
+
+func TestSpanFinishedImmutable(t *testing.T) {
+	sp := newTestTracer().New("root").(*Span)
+	sp.Finish(nil)
+	sp.SetTag(TagString(TagComponent, "late"))
+	sp.SetLog(Log(LogEvent, "late"))
+	sp.SetBaggageItem("late", "1")
+	sp.SetTitle("late")
+	assert.Equal(t, []Tag{SpanKindServerTag}, sp.Tags())
+	assert.Empty(t, sp.Logs())
+	assert.Empty(t, sp.BaggageItem("late"))
+	assert.Equal(t, "root", sp.OperationName())
+}
+
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+	sp := newTestTracer().New("root")
+	tr, ok := FromContext(NewContext(context.Background(), sp))
+	assert.True(t, ok)
+	assert.Equal(t, sp, tr)
+}
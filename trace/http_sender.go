@@ -0,0 +1,68 @@
+package trace
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Encoder encode a batch of spans to the wire format of backend.
+type Encoder interface {
+	// Encode encode spans to payload.
+	Encode(spans []*Span) ([]byte, error)
+	// ContentType return the http content type of payload.
+	ContentType() string
+}
+
+const _defaultHTTPTimeout = time.Second * 5
+
+var _ Sender = &httpSender{}
+
+type httpSender struct {
+	url     string
+	encoder Encoder
+	client  *http.Client
+}
+
+// NewHTTPSender new a sender which post spans encoded by encoder to url,
+// e.g. NewHTTPSender("http://127.0.0.1:9411/api/v2/spans", ZipkinEncoder)
+// or NewHTTPSender("http://127.0.0.1:4318/v1/traces", OTLPEncoder).
+// the default http client with 5s timeout is used if client is nil.
+func NewHTTPSender(url string, encoder Encoder, client *http.Client) Sender {
+	if client == nil {
+		client = &http.Client{Timeout: _defaultHTTPTimeout}
+	}
+	return &httpSender{url: url, encoder: encoder, client: client}
+}
+
+// Send post spans to backend.
+func (s *httpSender) Send(spans []*Span) error {
+	body, err := s.encoder.Encode(spans)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", s.encoder.ContentType())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain body for connection reuse.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("trace: post spans to %s failed, status code: %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// Close close idle connections.
+func (s *httpSender) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
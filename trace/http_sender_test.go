@@ -0,0 +1,111 @@
+package trace
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newEncoderSpans() []*Span {
+	tracer := newTestTracer()
+	root := tracer.New("root").(*Span)
+	child := root.Fork("", "child").SetTag(
+		TagString(TagPeerService, "redis"),
+		TagString(TagPeerIPv4, "127.0.0.1"),
+		TagInt(TagPeerPort, 6379),
+		TagInt(TagHTTPStatusCode, 503),
+	).(*Span)
+	err := errors.New("timeout")
+	child.Finish(&err)
+	root.Finish(nil)
+	return []*Span{child, root}
+}
+
+func TestZipkinEncoder(t *testing.T) {
+	spans := newEncoderSpans()
+	data, err := ZipkinEncoder.Encode(spans)
+	assert.NoError(t, err)
+	var zs []zipkinSpan
+	assert.NoError(t, json.Unmarshal(data, &zs))
+	assert.Len(t, zs, 2)
+	child := zs[0]
+	assert.Equal(t, spans[0].TraceID(), child.TraceID)
+	assert.Equal(t, spans[1].SpanID(), child.ParentID)
+	assert.Equal(t, "CLIENT", child.Kind)
+	assert.Equal(t, "SERVER", zs[1].Kind)
+	assert.Equal(t, &zipkinEndpoint{ServiceName: "redis", IPv4: "127.0.0.1", Port: 6379}, child.RemoteEndpoint)
+	assert.Equal(t, map[string]string{TagHTTPStatusCode: "503", TagError: "true"}, child.Tags)
+	assert.Equal(t, "message=timeout", child.Annotations[0].Value)
+}
+
+func TestOTLPEncoder(t *testing.T) {
+	spans := newEncoderSpans()
+	data, err := OTLPEncoder.Encode(spans)
+	assert.NoError(t, err)
+	var req otlpRequest
+	assert.NoError(t, json.Unmarshal(data, &req))
+	assert.Len(t, req.ResourceSpans, 1)
+	assert.Equal(t, "test", *req.ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+	os := req.ResourceSpans[0].ScopeSpans[0].Spans
+	assert.Len(t, os, 2)
+	child := os[0]
+	assert.Equal(t, _otlpKindClient, child.Kind)
+	assert.Equal(t, _otlpKindServer, os[1].Kind)
+	assert.Equal(t, otlpStatus{Code: _otlpStatusError, Message: "timeout"}, child.Status)
+	attrs := make(map[string]otlpAnyValue)
+	for _, kv := range child.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	assert.Equal(t, "503", *attrs["http.response.status_code"].IntValue)
+	assert.Equal(t, "6379", *attrs["network.peer.port"].IntValue)
+	assert.Equal(t, "redis", *attrs["peer.service"].StringValue)
+}
+
+func TestHTTPSender(t *testing.T) {
+	var body []byte
+	var contentType string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		contentType = r.Header.Get("Content-Type")
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := NewHTTPSender(srv.URL+"/api/v2/spans", ZipkinEncoder, nil)
+	spans := newEncoderSpans()
+	assert.NoError(t, s.Send(spans))
+	assert.Equal(t, "application/json", contentType)
+	expect, _ := ZipkinEncoder.Encode(spans)
+	assert.Equal(t, expect, body)
+
+	status = http.StatusBadRequest
+	assert.Error(t, s.Send(spans))
+	assert.NoError(t, s.Close())
+}
+
+func TestOTLPEncoderDistinctKeys(t *testing.T) {
+	sp := newTestTracer().New("root").SetTag(
+		TagString(TagPeerHostname, "redis.local"),
+		TagString(TagPeerAddress, "redis.local:6379"),
+		TagString(TagPeerIPv4, "127.0.0.1"),
+		TagString(TagPeerIPv6, "::1"),
+	).(*Span)
+	sp.Finish(nil)
+	o := toOTLPSpan(sp)
+	keys := make(map[string]string)
+	for _, kv := range o.Attributes {
+		_, dup := keys[kv.Key]
+		assert.False(t, dup, kv.Key)
+		keys[kv.Key] = *kv.Value.StringValue
+	}
+	assert.Equal(t, "redis.local", keys["server.address"])
+	assert.Equal(t, "redis.local:6379", keys[TagPeerAddress])
+	assert.Equal(t, "127.0.0.1", keys["network.peer.address"])
+	assert.Equal(t, "::1", keys[TagPeerIPv6])
+}
@@ -0,0 +1,68 @@
+package trace
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// JaegerTraceID jaeger trace header, see https://www.jaegertracing.io/docs/client-libraries/#propagation-format
+const JaegerTraceID = "uber-trace-id"
+
+const (
+	_jaegerFlagSampled = 0x01
+	_jaegerFlagDebug   = 0x02
+)
+
+var _ propagator = jaegerPropagator{}
+
+// jaegerPropagator propagate trace with uber-trace-id header:
+// uber-trace-id: {trace-id}:{span-id}:{parent-span-id}:{flags}
+type jaegerPropagator struct{}
+
+func (jaegerPropagator) Inject(sc spanContext, carrier Carrier) {
+	var flags byte
+	if sc.isSampled() {
+		flags |= _jaegerFlagSampled
+	}
+	if sc.isMustKeep() {
+		flags |= _jaegerFlagDebug
+	}
+	carrier.Set(JaegerTraceID, fmt.Sprintf("%s:%016x:%x:%x", sc.traceIDString(), sc.SpanID, sc.ParentID, flags))
+}
+
+func (jaegerPropagator) Extract(carrier Carrier) (sc spanContext, err error) {
+	value := carrier.Get(JaegerTraceID)
+	if value == "" {
+		return sc, ErrTraceNotFound
+	}
+	// some clients url-encode the value.
+	if v, err := url.QueryUnescape(value); err == nil {
+		value = v
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) != 4 {
+		return sc, ErrTraceCorrupted
+	}
+	if sc.TraceIDHigh, sc.TraceID, err = parseTraceID(parts[0]); err != nil {
+		return sc, ErrTraceCorrupted
+	}
+	if sc.SpanID, err = parseSpanID(parts[1]); err != nil {
+		return sc, ErrTraceCorrupted
+	}
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return sc, ErrTraceCorrupted
+	}
+	if flags&_jaegerFlagSampled != 0 {
+		sc.Flags |= flagSampled
+	}
+	if flags&_jaegerFlagDebug != 0 {
+		sc.Flags |= flagSampled | flagDebug | flagMustKeep
+	}
+	if !sc.IsValid() {
+		return sc, ErrTraceCorrupted
+	}
+	return sc, nil
+}
@@ -0,0 +1,88 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// jsonSpan is the json lines representation of span.
+type jsonSpan struct {
+	ServiceName   string                 `json:"service"`
+	OperationName string                 `json:"operation"`
+	TraceID       string                 `json:"trace_id"`
+	SpanID        string                 `json:"span_id"`
+	ParentID      string                 `json:"parent_id,omitempty"`
+	Start         int64                  `json:"start_us"`
+	Duration      int64                  `json:"duration_us"`
+	Tags          map[string]interface{} `json:"tags,omitempty"`
+	Logs          []jsonLog              `json:"logs,omitempty"`
+}
+
+type jsonLog struct {
+	Timestamp int64             `json:"timestamp_us"`
+	Fields    map[string]string `json:"fields"`
+}
+
+func toJSONSpan(sp *Span) *jsonSpan {
+	js := &jsonSpan{
+		ServiceName:   sp.ServiceName(),
+		OperationName: sp.OperationName(),
+		TraceID:       sp.TraceID(),
+		SpanID:        sp.SpanID(),
+		ParentID:      sp.ParentID(),
+		Start:         sp.StartTime().UnixMicro(),
+		Duration:      sp.Duration().Microseconds(),
+	}
+	if tags := sp.Tags(); len(tags) > 0 {
+		js.Tags = make(map[string]interface{}, len(tags))
+		for _, tag := range tags {
+			js.Tags[tag.Key] = tag.Value
+		}
+	}
+	for _, l := range sp.Logs() {
+		fields := make(map[string]string, len(l.Fields))
+		for _, f := range l.Fields {
+			fields[f.Key] = f.Value
+		}
+		js.Logs = append(js.Logs, jsonLog{Timestamp: l.Timestamp.UnixMicro(), Fields: fields})
+	}
+	return js
+}
+
+var _ Sender = &jsonSender{}
+
+type jsonSender struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+// NewJSONSender new a sender which writes spans to w as json lines,
+// w is closed on Close if it is an io.Closer.
+func NewJSONSender(w io.Writer) Sender {
+	return &jsonSender{w: w}
+}
+
+// Send write one json object per span per line.
+func (s *jsonSender) Send(spans []*Span) error {
+	enc := json.NewEncoder(&s.buf)
+	enc.SetEscapeHTML(false)
+	for _, sp := range spans {
+		s.buf.Reset()
+		if err := enc.Encode(toJSONSpan(sp)); err != nil {
+			return err
+		}
+		if _, err := s.w.Write(s.buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close close the underlying writer.
+func (s *jsonSender) Close() error {
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
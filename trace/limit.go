@@ -0,0 +1,104 @@
+package trace
+
+import (
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// default span limits, negative value in Config meaning unlimit.
+const (
+	_defaultMaxTagsPerSpan   = 128
+	_defaultMaxLogsPerSpan   = 256
+	_defaultMaxValueLength   = 4096
+	_defaultMaxSpansPerTrace = 10000
+)
+
+// LimitStats counts of tags, logs and spans truncated or dropped by span limits.
+type LimitStats struct {
+	DroppedTags     int64
+	DroppedLogs     int64
+	TruncatedValues int64
+	DroppedSpans    int64
+}
+
+type spanLimits struct {
+	maxTagsPerSpan   int
+	maxLogsPerSpan   int
+	maxValueLength   int
+	maxSpansPerTrace int64
+
+	droppedTags     atomic.Int64
+	droppedLogs     atomic.Int64
+	truncatedValues atomic.Int64
+	droppedSpans    atomic.Int64
+}
+
+func newSpanLimits(c *Config) *spanLimits {
+	return &spanLimits{
+		maxTagsPerSpan:   c.MaxTagsPerSpan,
+		maxLogsPerSpan:   c.MaxLogsPerSpan,
+		maxValueLength:   c.MaxValueLength,
+		maxSpansPerTrace: int64(c.MaxSpansPerTrace),
+	}
+}
+
+func (l *spanLimits) stats() LimitStats {
+	return LimitStats{
+		DroppedTags:     l.droppedTags.Load(),
+		DroppedLogs:     l.droppedLogs.Load(),
+		TruncatedValues: l.truncatedValues.Load(),
+		DroppedSpans:    l.droppedSpans.Load(),
+	}
+}
+
+// allowTag return false and count it if span already has max tags.
+func (l *spanLimits) allowTag(n int) bool {
+	if l.maxTagsPerSpan >= 0 && n >= l.maxTagsPerSpan {
+		l.droppedTags.Add(1)
+		return false
+	}
+	return true
+}
+
+// allowLog return false and count it if span already has max logs.
+func (l *spanLimits) allowLog(n int) bool {
+	if l.maxLogsPerSpan >= 0 && n >= l.maxLogsPerSpan {
+		l.droppedLogs.Add(1)
+		return false
+	}
+	return true
+}
+
+// allowSpan return false and count it if trace already has max spans in process.
+func (l *spanLimits) allowSpan(n int64) bool {
+	if l.maxSpansPerTrace >= 0 && n > l.maxSpansPerTrace {
+		l.droppedSpans.Add(1)
+		return false
+	}
+	return true
+}
+
+// truncate truncate s to max value length on utf8 boundary.
+func (l *spanLimits) truncate(s string) string {
+	if l.maxValueLength < 0 || len(s) <= l.maxValueLength {
+		return s
+	}
+	l.truncatedValues.Add(1)
+	s = s[:l.maxValueLength]
+	// drop the incomplete rune at the end.
+	for i := 0; i < utf8.UTFMax-1 && len(s) > 0; i++ {
+		if r, size := utf8.DecodeLastRuneInString(s); r != utf8.RuneError || size != 1 {
+			break
+		}
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// GetLimitStats return the limit stats of global tracer.
+func GetLimitStats() LimitStats {
+	if d, ok := _tracer.(*dapper); ok {
+		return d.limits.stats()
+	}
+	return LimitStats{}
+}
@@ -0,0 +1,55 @@
+package trace
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpanLimits(t *testing.T) {
+	r := &mockSender{}
+	tracer := newTracer(&Config{
+		ServiceName:      "test",
+		DisableSample:    true,
+		MaxTagsPerSpan:   2,
+		MaxLogsPerSpan:   1,
+		MaxValueLength:   8,
+		MaxSpansPerTrace: 2,
+		Reporter:         reporterFunc(func(sp *Span) error { return r.Send([]*Span{sp}) }),
+	})
+	root := tracer.New("root").(*Span)
+	root.SetTag(DBStatement("SELECT 1 FROM t"), PeerService("db"), SpanKind("client"))
+	assert.Equal(t, []Tag{SpanKind("client"), DBStatement("SELECT 1")}, root.Tags())
+
+	root.SetLog(Log(LogMessage, "你好世界")).SetLog(Log(LogMessage, "dropped"))
+	assert.Len(t, root.Logs(), 1)
+	assert.Equal(t, "你好", root.Logs()[0].Fields[0].Value)
+
+	child := root.Fork("", "child")
+	over := root.Fork("", "over")
+	assert.NotEmpty(t, over.SpanID())
+	over.Finish(nil)
+	child.Finish(nil)
+	root.Finish(nil)
+	assert.Equal(t, 2, r.count())
+
+	assert.Equal(t, LimitStats{DroppedTags: 1, DroppedLogs: 1, TruncatedValues: 2, DroppedSpans: 1}, tracer.LimitStats())
+}
+
+func TestSpanLimitsUnlimit(t *testing.T) {
+	tracer := newTracer(&Config{ServiceName: "test", DisableSample: true, MaxValueLength: -1, MaxTagsPerSpan: -1})
+	root := tracer.New("root").(*Span)
+	long := strings.Repeat("a", 10000)
+	for i := 0; i < 200; i++ {
+		root.SetTag(TagString(strings.Repeat("k", i+1), long))
+	}
+	assert.Len(t, root.Tags(), 201)
+	assert.Equal(t, LimitStats{}, tracer.LimitStats())
+}
+
+type reporterFunc func(sp *Span) error
+
+func (f reporterFunc) WriteSpan(sp *Span) error { return f(sp) }
+
+func (f reporterFunc) Close() error { return nil }
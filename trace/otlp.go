@@ -0,0 +1,220 @@
+package trace
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+var _ Encoder = otlpEncoder{}
+
+// OTLPEncoder encode spans to OTLP/HTTP json, see https://opentelemetry.io/docs/specs/otlp/#otlphttp
+var OTLPEncoder Encoder = otlpEncoder{}
+
+// otlp span kind and status code.
+const (
+	_otlpKindInternal = 1
+	_otlpKindServer   = 2
+	_otlpKindClient   = 3
+	_otlpKindProducer = 4
+	_otlpKindConsumer = 5
+
+	_otlpStatusError = 2
+
+	_otlpScopeName = "MagicWand/trace"
+)
+
+// _otlpAttributeKeys map standard tags to otel semantic conventions, each tag
+// to a distinct key as duplicated attribute keys are forbidden, the tags not
+// listed such as peer.address and peer.ipv6 keep their own keys.
+var _otlpAttributeKeys = map[string]string{
+	TagPeerService:    "peer.service",
+	TagPeerHostname:   "server.address",
+	TagPeerIPv4:       "network.peer.address",
+	TagPeerPort:       "network.peer.port",
+	TagHTTPStatusCode: "http.response.status_code",
+	TagHTTPMethod:     "http.request.method",
+	TagHTTPURL:        "url.full",
+	TagDBType:         "db.system",
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope   `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpEncoder struct{}
+
+func (otlpEncoder) ContentType() string {
+	return "application/json"
+}
+
+func (otlpEncoder) Encode(spans []*Span) ([]byte, error) {
+	req := &otlpRequest{}
+	// group spans by service name as resource.
+	resources := make(map[string]*otlpResourceSpans)
+	for _, sp := range spans {
+		rs, ok := resources[sp.ServiceName()]
+		if !ok {
+			rs = &otlpResourceSpans{
+				Resource:   otlpResource{Attributes: []otlpKeyValue{otlpAttribute("service.name", sp.ServiceName())}},
+				ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: _otlpScopeName}}},
+			}
+			resources[sp.ServiceName()] = rs
+			req.ResourceSpans = append(req.ResourceSpans, rs)
+		}
+		rs.ScopeSpans[0].Spans = append(rs.ScopeSpans[0].Spans, toOTLPSpan(sp))
+	}
+	return json.Marshal(req)
+}
+
+func toOTLPSpan(sp *Span) *otlpSpan {
+	o := &otlpSpan{
+		TraceID:           sp.spanContext().otlpTraceID(),
+		SpanID:            sp.SpanID(),
+		ParentSpanID:      sp.ParentID(),
+		Name:              sp.OperationName(),
+		Kind:              _otlpKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(sp.StartTime().UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(sp.StartTime().Add(sp.Duration()).UnixNano(), 10),
+	}
+	for _, tag := range sp.Tags() {
+		switch tag.Key {
+		case TagSpanKind:
+			if kind, ok := otlpKind(tag.Value); ok {
+				o.Kind = kind
+				continue
+			}
+		case TagError:
+			if b, ok := tag.Value.(bool); ok {
+				if b {
+					o.Status.Code = _otlpStatusError
+				}
+				continue
+			}
+		case TagHTTPStatusCode, TagPeerPort:
+			if i, ok := tagInt(tag.Value); ok {
+				o.Attributes = append(o.Attributes, otlpAttribute(_otlpAttributeKeys[tag.Key], i))
+				continue
+			}
+		}
+		key := tag.Key
+		if k, ok := _otlpAttributeKeys[key]; ok {
+			key = k
+		}
+		o.Attributes = append(o.Attributes, otlpAttribute(key, tag.Value))
+	}
+	for _, l := range sp.Logs() {
+		e := otlpEvent{TimeUnixNano: strconv.FormatInt(l.Timestamp.UnixNano(), 10), Name: "log"}
+		for _, f := range l.Fields {
+			switch f.Key {
+			case LogEvent:
+				e.Name = f.Value
+			case LogMessage:
+				if o.Status.Code == _otlpStatusError && o.Status.Message == "" {
+					o.Status.Message = f.Value
+				}
+				fallthrough
+			default:
+				e.Attributes = append(e.Attributes, otlpAttribute(f.Key, f.Value))
+			}
+		}
+		o.Events = append(o.Events, e)
+	}
+	return o
+}
+
+// otlpTraceID otlp trace id must be 32 hex characters.
+func (c spanContext) otlpTraceID() string {
+	if c.TraceIDHigh == 0 {
+		return "0000000000000000" + c.traceIDString()
+	}
+	return c.traceIDString()
+}
+
+func otlpKind(v interface{}) (int, bool) {
+	switch tagString(v) {
+	case "client":
+		return _otlpKindClient, true
+	case "server":
+		return _otlpKindServer, true
+	case "producer":
+		return _otlpKindProducer, true
+	case "consumer":
+		return _otlpKindConsumer, true
+	}
+	return 0, false
+}
+
+func otlpAttribute(key string, v interface{}) otlpKeyValue {
+	kv := otlpKeyValue{Key: key}
+	switch v := v.(type) {
+	case bool:
+		kv.Value.BoolValue = &v
+	case int, int32, int64:
+		i, _ := tagInt(v)
+		s := strconv.FormatInt(i, 10)
+		kv.Value.IntValue = &s
+	case float32:
+		f := float64(v)
+		kv.Value.DoubleValue = &f
+	case float64:
+		kv.Value.DoubleValue = &v
+	default:
+		s := tagString(v)
+		kv.Value.StringValue = &s
+	}
+	return kv
+}
@@ -0,0 +1,162 @@
+package trace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedFormat occurs when the `format` passed to Tracer.Inject() or
+	// Tracer.Extract() is not recognized by the Tracer implementation.
+	ErrUnsupportedFormat = errors.New("trace: Unknown or unsupported Inject/Extract format")
+
+	// ErrTraceNotFound occurs when the `carrier` passed to
+	// Tracer.Extract() is valid and uncorrupted but has insufficient
+	// information to extract a Trace.
+	ErrTraceNotFound = errors.New("trace: Trace not found in Extract carrier")
+
+	// ErrInvalidTrace errors occur when Tracer.Inject() is asked to
+	// operate on a Trace which it is not prepared to handle (for
+	// example, since it was created by a different tracer implementation).
+	ErrInvalidTrace = errors.New("trace: Trace type incompatible with tracer")
+
+	// ErrInvalidCarrier errors occur when Tracer.Inject() or Tracer.Extract()
+	// implementations expect a different type of `carrier` than they are
+	// given.
+	ErrInvalidCarrier = errors.New("trace: Invalid Inject/Extract carrier")
+
+	// ErrTraceCorrupted occurs when the `carrier` passed to
+	// Tracer.Extract() is of the expected type but is corrupted.
+	ErrTraceCorrupted = errors.New("trace: Trace data corrupted in Extract carrier")
+)
+
+// BuiltinFormat is used to demarcate the values within package `trace`
+// that are intended for use with the Tracer.Inject() and Tracer.Extract() methods.
+type BuiltinFormat byte
+
+// support format list
+const (
+	// HTTPFormat represents Trace as HTTP header string pairs.
+	//
+	// the HTTPFormat format requires that the keys and values
+	// be valid as HTTP headers as-is (i.e., character casing may be unstable
+	// and special characters are disallowed in keys, values should be
+	// URL-escaped, etc).
+	//
+	// the carrier must be a `http.Header`.
+	HTTPFormat BuiltinFormat = iota
+	// MapFormat represents Trace as metadata string pairs.
+	//
+	// the carrier must be a `map[string]string`, keys are lower case.
+	MapFormat
+)
+
+// Carrier propagator must convert generic interface{} to something this
+// implement Carrier interface, Trace can use Carrier to represents itself.
+type Carrier interface {
+	Set(key, val string)
+	Get(key string) string
+}
+
+// propagator is responsible for injecting and extracting `Trace` instances
+// from a format-specific "carrier"
+type propagator interface {
+	Inject(sc spanContext, carrier Carrier)
+	Extract(carrier Carrier) (spanContext, error)
+}
+
+// propagator names used by Config.Propagators.
+const (
+	PropagatorW3C     = "w3c"
+	PropagatorB3      = "b3"
+	PropagatorB3Multi = "b3multi"
+	PropagatorJaeger  = "jaeger"
+)
+
+var _propagators = map[string]propagator{
+	PropagatorW3C:     w3cPropagator{},
+	PropagatorB3:      b3Propagator{},
+	PropagatorB3Multi: b3MultiPropagator{},
+	PropagatorJaeger:  jaegerPropagator{},
+}
+
+// newPropagator return the propagator by names, composite them if more than one.
+func newPropagator(names ...string) (propagator, error) {
+	var ps compositePropagator
+	for _, name := range names {
+		p, ok := _propagators[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("trace: unknown propagator %q", name)
+		}
+		ps = append(ps, p)
+	}
+	if len(ps) == 1 {
+		return ps[0], nil
+	}
+	return ps, nil
+}
+
+// compositePropagator inject all formats, and extract with the first
+// format found in carrier.
+type compositePropagator []propagator
+
+func (ps compositePropagator) Inject(sc spanContext, carrier Carrier) {
+	for _, p := range ps {
+		p.Inject(sc, carrier)
+	}
+}
+
+func (ps compositePropagator) Extract(carrier Carrier) (sc spanContext, err error) {
+	err = ErrTraceNotFound
+	for _, p := range ps {
+		c, e := p.Extract(carrier)
+		if e == nil {
+			return c, nil
+		}
+		if e != ErrTraceNotFound {
+			err = e
+		}
+	}
+	return sc, err
+}
+
+type httpCarrier http.Header
+
+func (h httpCarrier) Set(key, val string) {
+	http.Header(h).Set(key, val)
+}
+
+func (h httpCarrier) Get(key string) string {
+	return http.Header(h).Get(key)
+}
+
+type mapCarrier map[string]string
+
+func (m mapCarrier) Set(key, val string) {
+	m[strings.ToLower(key)] = val
+}
+
+func (m mapCarrier) Get(key string) string {
+	return m[strings.ToLower(key)]
+}
+
+// toCarrier convert the carrier of format to Carrier.
+func toCarrier(format interface{}, carrier interface{}) (Carrier, error) {
+	switch format {
+	case HTTPFormat:
+		header, ok := carrier.(http.Header)
+		if !ok {
+			return nil, ErrInvalidCarrier
+		}
+		return httpCarrier(header), nil
+	case MapFormat:
+		md, ok := carrier.(map[string]string)
+		if !ok || md == nil {
+			return nil, ErrInvalidCarrier
+		}
+		return mapCarrier(md), nil
+	}
+	return nil, ErrUnsupportedFormat
+}
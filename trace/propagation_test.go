@@ -0,0 +1,155 @@
+package trace
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestW3CInjectExtract(t *testing.T) {
+	tracer := newTestTracer()
+	root := tracer.New("root").(*Span)
+
+	header := make(http.Header)
+	assert.NoError(t, tracer.Inject(root, HTTPFormat, header))
+	assert.Equal(t, "00-"+root.TraceID()+"-"+root.SpanID()+"-01", header.Get(W3CTraceParent))
+	assert.Empty(t, header.Get(W3CTraceState))
+
+	tr, err := tracer.Extract(HTTPFormat, header)
+	assert.NoError(t, err)
+	sp := tr.(*Span)
+	assert.Equal(t, root.TraceID(), sp.TraceID())
+	assert.Equal(t, root.SpanID(), sp.ParentID())
+	assert.True(t, sp.IsSampled())
+}
+
+func TestW3CMustKeep(t *testing.T) {
+	tracer := newTestTracer()
+	root := tracer.New("root", EnableDebug())
+	md := map[string]string{}
+	assert.NoError(t, tracer.Inject(root, MapFormat, md))
+	assert.Equal(t, "magicwand=p:999", md[W3CTraceState])
+
+	md[W3CTraceState] = "magicwand=p:999,congo=t61rcWkgMzE"
+	tr, err := tracer.Extract(MapFormat, md)
+	assert.NoError(t, err)
+	assert.True(t, tr.IsSampled())
+	assert.Contains(t, tr.(*Span).Tags(), TagString(TagSamplingPriority, HighestSamplingPriority))
+
+	next := map[string]string{}
+	assert.NoError(t, tracer.Inject(tr.Fork("", "child"), MapFormat, next))
+	assert.Equal(t, "magicwand=p:999,congo=t61rcWkgMzE", next[W3CTraceState])
+}
+
+func TestW3CExtractUnsampled(t *testing.T) {
+	md := map[string]string{W3CTraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"}
+	tr, err := newTestTracer().Extract(MapFormat, md)
+	assert.NoError(t, err)
+	assert.False(t, tr.IsSampled())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", tr.TraceID())
+}
+
+func TestW3CExtractError(t *testing.T) {
+	tracer := newTestTracer()
+	for tp, expect := range map[string]error{
+		"": ErrTraceNotFound,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7":       ErrTraceCorrupted,
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01":    ErrTraceCorrupted,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01":    ErrTraceCorrupted,
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01":    ErrTraceCorrupted,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-xx": ErrTraceCorrupted,
+	} {
+		_, err := tracer.Extract(MapFormat, map[string]string{W3CTraceParent: tp})
+		assert.Equal(t, expect, err, tp)
+	}
+	_, err := tracer.Extract(HTTPFormat, map[string]string{})
+	assert.Equal(t, ErrInvalidCarrier, err)
+	_, err = tracer.Extract("unknown", map[string]string{})
+	assert.Equal(t, ErrUnsupportedFormat, err)
+}
+
+func TestB3Propagation(t *testing.T) {
+	tracer := newTracer(&Config{ServiceName: "test", DisableSample: true, Propagators: []string{PropagatorB3, PropagatorB3Multi}})
+	root := tracer.New("root").(*Span)
+	header := make(http.Header)
+	assert.NoError(t, tracer.Inject(root, HTTPFormat, header))
+	assert.Equal(t, root.TraceID()+"-"+root.SpanID()+"-1", header.Get(B3Single))
+	assert.Equal(t, root.TraceID(), header.Get(B3TraceID))
+	assert.Equal(t, root.SpanID(), header.Get(B3SpanID))
+	assert.Equal(t, "1", header.Get(B3Sampled))
+
+	md := map[string]string{
+		"x-b3-traceid": "463ac35c9f6413ad",
+		"x-b3-spanid":  "a2fb4a1d1a96d312",
+		"x-b3-flags":   "1",
+	}
+	tr, err := tracer.Extract(MapFormat, md)
+	assert.NoError(t, err)
+	assert.Equal(t, "463ac35c9f6413ad", tr.TraceID())
+	assert.Equal(t, "a2fb4a1d1a96d312", tr.(*Span).ParentID())
+	assert.True(t, tr.IsSampled())
+	assert.Contains(t, tr.(*Span).Tags(), TagString(TagSamplingPriority, HighestSamplingPriority))
+
+	_, err = tracer.Extract(MapFormat, map[string]string{B3Single: "463ac35c9f6413ad-a2fb4a1d1a96d312-x"})
+	assert.Equal(t, ErrTraceCorrupted, err)
+}
+
+func TestJaegerPropagation(t *testing.T) {
+	tracer := newTracer(&Config{ServiceName: "test", DisableSample: true, Propagators: []string{PropagatorJaeger}})
+	root := tracer.New("root", EnableDebug()).(*Span)
+	md := map[string]string{}
+	assert.NoError(t, tracer.Inject(root, MapFormat, md))
+	assert.Equal(t, root.TraceID()+":"+root.SpanID()+":0:3", md[JaegerTraceID])
+
+	tr, err := tracer.Extract(MapFormat, map[string]string{JaegerTraceID: "463ac35c9f6413ad%3Aa2fb4a1d1a96d312%3A0%3A0"})
+	assert.NoError(t, err)
+	assert.Equal(t, "463ac35c9f6413ad", tr.TraceID())
+	assert.False(t, tr.IsSampled())
+}
+
+func TestCompositePropagation(t *testing.T) {
+	tracer := newTracer(&Config{ServiceName: "test", DisableSample: true, Propagators: []string{PropagatorW3C, PropagatorB3, PropagatorJaeger}})
+	for _, md := range []map[string]string{
+		{W3CTraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{B3Single: "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1"},
+		{JaegerTraceID: "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1"},
+		{W3CTraceParent: "corrupted", JaegerTraceID: "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1"},
+	} {
+		tr, err := tracer.Extract(MapFormat, md)
+		assert.NoError(t, err)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", tr.TraceID())
+
+		next := map[string]string{}
+		assert.NoError(t, tracer.Inject(tr, MapFormat, next))
+		assert.Contains(t, next[W3CTraceParent], "4bf92f3577b34da6a3ce929d0e0e4736")
+		assert.Contains(t, next[B3Single], "4bf92f3577b34da6a3ce929d0e0e4736")
+		assert.Contains(t, next[JaegerTraceID], "4bf92f3577b34da6a3ce929d0e0e4736")
+	}
+	_, err := tracer.Extract(MapFormat, map[string]string{W3CTraceParent: "corrupted"})
+	assert.Equal(t, ErrTraceCorrupted, err)
+	_, err = newPropagator("unknown")
+	assert.Error(t, err)
+}
+
+func TestBaggage(t *testing.T) {
+	tracer := newTestTracer()
+	root := tracer.New("root")
+	root.SetBaggageItem("tenant", "a b").SetBaggageItem("color", "red,blue")
+	child := root.Fork("", "child")
+	root.SetBaggageItem("late", "1")
+	assert.Equal(t, "a b", child.BaggageItem("tenant"))
+	assert.Equal(t, "", child.BaggageItem("late"))
+	assert.Equal(t, "1", root.BaggageItem("late"))
+
+	header := make(http.Header)
+	assert.NoError(t, tracer.Inject(child, HTTPFormat, header))
+	assert.Equal(t, "color=red%2Cblue,tenant=a%20b", header.Get(W3CBaggage))
+
+	header.Set(W3CBaggage, header.Get(W3CBaggage)+", invalid ,exp = 1;prop=x")
+	tr, err := tracer.Extract(HTTPFormat, header)
+	assert.NoError(t, err)
+	assert.Equal(t, "a b", tr.BaggageItem("tenant"))
+	assert.Equal(t, "red,blue", tr.BaggageItem("color"))
+	assert.Equal(t, "1", tr.BaggageItem("exp"))
+}
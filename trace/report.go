@@ -0,0 +1,182 @@
+package trace
+
+import (
+	"errors"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrReporterFull the queue of reporter is full, span is dropped.
+	ErrReporterFull = errors.New("trace: reporter queue is full, span dropped")
+	// ErrReporterClosed reporter already closed.
+	ErrReporterClosed = errors.New("trace: reporter already closed")
+)
+
+// Reporter is called by tracer when a sampled span is finished.
+type Reporter interface {
+	// WriteSpan report a finished span.
+	// NOTE: the span must not be modified after WriteSpan.
+	WriteSpan(sp *Span) error
+	// Close flush the spans and release resource.
+	Close() error
+}
+
+// Sender send a batch of spans to the backend.
+type Sender interface {
+	// Send send spans, spans is reused after Send return.
+	Send(spans []*Span) error
+	// Close release resource.
+	Close() error
+}
+
+var _ Reporter = &AsyncReporter{}
+
+type reporterOption struct {
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+var defaultReporterOption = reporterOption{
+	QueueSize:     4096,
+	BatchSize:     128,
+	FlushInterval: time.Second,
+}
+
+// ReporterOption async reporter option.
+type ReporterOption func(*reporterOption)
+
+// QueueSize set max spans waiting in queue, default 4096.
+// spans are dropped when queue is full.
+func QueueSize(n int) ReporterOption {
+	if n <= 0 {
+		panic("trace: queue size should > 0")
+	}
+	return func(o *reporterOption) {
+		o.QueueSize = n
+	}
+}
+
+// BatchSize set max spans send in one batch, default 128.
+func BatchSize(n int) ReporterOption {
+	if n <= 0 {
+		panic("trace: batch size should > 0")
+	}
+	return func(o *reporterOption) {
+		o.BatchSize = n
+	}
+}
+
+// FlushInterval set the interval to send incomplete batch, default 1s.
+func FlushInterval(d time.Duration) ReporterOption {
+	if d <= 0 {
+		panic("trace: flush interval should > 0")
+	}
+	return func(o *reporterOption) {
+		o.FlushInterval = d
+	}
+}
+
+// AsyncReporter queue spans in a bounded queue, and send them by batch in background.
+type AsyncReporter struct {
+	sender Sender
+	opt    reporterOption
+	ch     chan *Span
+	stdlog *log.Logger
+
+	dropped atomic.Int64
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewAsyncReporter new a async reporter send spans by sender.
+func NewAsyncReporter(sender Sender, opts ...ReporterOption) *AsyncReporter {
+	opt := defaultReporterOption
+	for _, fn := range opts {
+		fn(&opt)
+	}
+	r := &AsyncReporter{
+		sender: sender,
+		opt:    opt,
+		ch:     make(chan *Span, opt.QueueSize),
+		stdlog: log.New(os.Stderr, "trace ", log.LstdFlags),
+	}
+	r.wg.Add(1)
+	go r.daemon()
+	return r
+}
+
+// WriteSpan put span in queue, drop it if queue is full.
+func (r *AsyncReporter) WriteSpan(sp *Span) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		r.dropped.Add(1)
+		return ErrReporterClosed
+	}
+	select {
+	case r.ch <- sp:
+		return nil
+	default:
+		r.dropped.Add(1)
+		return ErrReporterFull
+	}
+}
+
+// Dropped return the count of spans dropped.
+func (r *AsyncReporter) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+func (r *AsyncReporter) daemon() {
+	defer r.wg.Done()
+	tk := time.NewTicker(r.opt.FlushInterval)
+	defer tk.Stop()
+	batch := make([]*Span, 0, r.opt.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.sender.Send(batch); err != nil {
+			r.dropped.Add(int64(len(batch)))
+			r.stdlog.Printf("failed to send %d spans: %s", len(batch), err)
+		}
+		clear(batch)
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case sp, ok := <-r.ch:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, sp)
+			if len(batch) >= r.opt.BatchSize {
+				flush()
+			}
+		case <-tk.C:
+			flush()
+		}
+	}
+}
+
+// Close flush spans in queue and close sender.
+func (r *AsyncReporter) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	close(r.ch)
+	r.mu.Unlock()
+	r.wg.Wait()
+	return r.sender.Close()
+}
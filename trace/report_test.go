@@ -0,0 +1,99 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSender struct {
+	mu      sync.Mutex
+	batches [][]*Span
+	block   chan struct{}
+	closed  bool
+}
+
+func (m *mockSender) Send(spans []*Span) error {
+	if m.block != nil {
+		<-m.block
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches = append(m.batches, append([]*Span(nil), spans...))
+	return nil
+}
+
+func (m *mockSender) Close() error {
+	m.closed = true
+	return nil
+}
+
+func (m *mockSender) count() (n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, b := range m.batches {
+		n += len(b)
+	}
+	return
+}
+
+func TestAsyncReporterBatch(t *testing.T) {
+	sender := &mockSender{}
+	r := NewAsyncReporter(sender, BatchSize(2), FlushInterval(time.Hour))
+	tracer := newTracer(&Config{ServiceName: "test", DisableSample: true, Reporter: r})
+	for i := 0; i < 5; i++ {
+		tracer.New("root").Finish(nil)
+	}
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, 4, sender.count())
+
+	assert.NoError(t, tracer.Close())
+	assert.True(t, sender.closed)
+	assert.Equal(t, 5, sender.count())
+	assert.Equal(t, ErrReporterClosed, r.WriteSpan(tracer.New("root").(*Span)))
+	assert.Equal(t, int64(1), r.Dropped())
+}
+
+func TestAsyncReporterDrop(t *testing.T) {
+	sender := &mockSender{block: make(chan struct{})}
+	r := NewAsyncReporter(sender, BatchSize(1), QueueSize(1))
+	tracer := newTracer(&Config{ServiceName: "test", DisableSample: true})
+	var dropped int
+	for i := 0; i < 10; i++ {
+		if r.WriteSpan(tracer.New("root").(*Span)) == ErrReporterFull {
+			dropped++
+		}
+	}
+	assert.True(t, dropped >= 8)
+	assert.Equal(t, int64(dropped), r.Dropped())
+	close(sender.block)
+	r.Close()
+	assert.Equal(t, 10-dropped, sender.count())
+}
+
+func TestJSONSender(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewJSONSender(&buf)
+	tracer := newTestTracer()
+	root := tracer.New("root").(*Span)
+	child := root.Fork("", "child").SetTag(TagInt(TagHTTPStatusCode, 200)).SetLog(Log(LogEvent, "done")).(*Span)
+	child.Finish(nil)
+	root.Finish(nil)
+	assert.NoError(t, s.Send([]*Span{child, root}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	var js map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &js))
+	assert.Equal(t, "test", js["service"])
+	assert.Equal(t, "child", js["operation"])
+	assert.Equal(t, root.TraceID(), js["trace_id"])
+	assert.Equal(t, root.SpanID(), js["parent_id"])
+	assert.Equal(t, float64(200), js["tags"].(map[string]interface{})[TagHTTPStatusCode])
+	assert.Equal(t, "done", js["logs"].([]interface{})[0].(map[string]interface{})["fields"].(map[string]interface{})[LogEvent])
+}
@@ -0,0 +1,150 @@
+package trace
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+var ignoreds = []string{"/metrics", "/ping"}
+
+// Sampler decides whether a new trace should be sampled or not.
+type Sampler interface {
+	// IsSampled return true if the trace with traceID and operationName should be sampled.
+	IsSampled(traceID uint64, operationName string) bool
+	// Close release resource of sampler.
+	Close() error
+}
+
+var (
+	_ Sampler = &probabilitySampling{}
+	_ Sampler = &rateLimitingSampling{}
+	_ Sampler = constSampling(true)
+	_ Sampler = &operationSampling{}
+)
+
+type probabilitySampling struct {
+	probability float32
+}
+
+func (p *probabilitySampling) IsSampled(traceID uint64, operationName string) bool {
+	for _, ignored := range ignoreds {
+		if operationName == ignored {
+			return false
+		}
+	}
+	return rand.Float32() < p.probability
+}
+
+func (p *probabilitySampling) Close() error { return nil }
+
+// NewProbabilitySampler new a sampler which samples traces with probability.
+// NOTE: probability P ∈ (0, 1], panic if out of range.
+func NewProbabilitySampler(probability float32) Sampler {
+	if probability <= 0 || probability > 1 {
+		panic("trace: probability P ∈ (0, 1]")
+	}
+	return &probabilitySampling{probability: probability}
+}
+
+// rateLimitingSampling token bucket sampler.
+type rateLimitingSampling struct {
+	mu         sync.Mutex
+	perSecond  float64
+	balance    float64
+	maxBalance float64
+	lastTick   time.Time
+	now        func() time.Time
+}
+
+func (r *rateLimitingSampling) IsSampled(traceID uint64, operationName string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.balance += now.Sub(r.lastTick).Seconds() * r.perSecond
+	r.lastTick = now
+	if r.balance > r.maxBalance {
+		r.balance = r.maxBalance
+	}
+	if r.balance >= 1 {
+		r.balance--
+		return true
+	}
+	return false
+}
+
+func (r *rateLimitingSampling) Close() error { return nil }
+
+// NewRateLimitingSampler new a sampler which samples at most n traces per second.
+// NOTE: n must be greater than 0, panic otherwise.
+func NewRateLimitingSampler(n float64) Sampler {
+	if n <= 0 {
+		panic("trace: rate limiting sampler n should > 0")
+	}
+	maxBalance := n
+	if maxBalance < 1 {
+		maxBalance = 1
+	}
+	return &rateLimitingSampling{
+		perSecond:  n,
+		balance:    maxBalance,
+		maxBalance: maxBalance,
+		lastTick:   time.Now(),
+		now:        time.Now,
+	}
+}
+
+type constSampling bool
+
+func (c constSampling) IsSampled(traceID uint64, operationName string) bool { return bool(c) }
+
+func (c constSampling) Close() error { return nil }
+
+// AlwaysSample return a sampler which samples every trace.
+func AlwaysSample() Sampler {
+	return constSampling(true)
+}
+
+// NeverSample return a sampler which samples no trace,
+// trace can still be kept by TagSamplingPriority.
+func NeverSample() Sampler {
+	return constSampling(false)
+}
+
+// operationSampling choose sampler by operation name.
+type operationSampling struct {
+	defaultSampler Sampler
+	operations     map[string]Sampler
+}
+
+func (o *operationSampling) IsSampled(traceID uint64, operationName string) bool {
+	if s, ok := o.operations[operationName]; ok {
+		return s.IsSampled(traceID, operationName)
+	}
+	return o.defaultSampler.IsSampled(traceID, operationName)
+}
+
+func (o *operationSampling) Close() (err error) {
+	for _, s := range o.operations {
+		if e := s.Close(); e != nil {
+			err = e
+		}
+	}
+	if e := o.defaultSampler.Close(); e != nil {
+		err = e
+	}
+	return
+}
+
+// NewOperationSampler new a sampler which use the sampler of operations by
+// operation name, and defaultSampler for operation not in operations.
+func NewOperationSampler(defaultSampler Sampler, operations map[string]Sampler) Sampler {
+	if defaultSampler == nil {
+		panic("trace: operation sampler default sampler should not be nil")
+	}
+	ops := make(map[string]Sampler, len(operations))
+	for name, s := range operations {
+		ops[name] = s
+	}
+	return &operationSampling{defaultSampler: defaultSampler, operations: ops}
+}
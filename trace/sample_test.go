@@ -0,0 +1,63 @@
+package trace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbabilitySampler(t *testing.T) {
+	s := NewProbabilitySampler(0.01)
+	count := 0
+	for i := 0; i < 100000; i++ {
+		if s.IsSampled(0, "test123") {
+			count++
+		}
+	}
+	assert.InDelta(t, 1000, count, 300)
+	assert.False(t, NewProbabilitySampler(1).IsSampled(0, "/metrics"))
+	assert.Panics(t, func() { NewProbabilitySampler(0) })
+}
+
+func TestRateLimitingSampler(t *testing.T) {
+	s := NewRateLimitingSampler(2).(*rateLimitingSampling)
+	now := time.Now()
+	s.lastTick = now
+	s.now = func() time.Time { return now }
+	assert.True(t, s.IsSampled(0, "test"))
+	assert.True(t, s.IsSampled(0, "test"))
+	assert.False(t, s.IsSampled(0, "test"))
+
+	now = now.Add(time.Millisecond * 500)
+	assert.True(t, s.IsSampled(0, "test"))
+	assert.False(t, s.IsSampled(0, "test"))
+
+	now = now.Add(time.Hour)
+	assert.True(t, s.IsSampled(0, "test"))
+	assert.True(t, s.IsSampled(0, "test"))
+	assert.False(t, s.IsSampled(0, "test"))
+}
+
+func TestOperationSampler(t *testing.T) {
+	s := NewOperationSampler(NeverSample(), map[string]Sampler{"keep": AlwaysSample()})
+	assert.True(t, s.IsSampled(0, "keep"))
+	assert.False(t, s.IsSampled(0, "other"))
+}
+
+func TestSamplingPriority(t *testing.T) {
+	tracer := newTracer(&Config{ServiceName: "test", Sampler: NeverSample()})
+	root := tracer.New("root")
+	child := root.Fork("", "child")
+	assert.False(t, root.IsSampled())
+
+	child.SetTag(TagString(TagSamplingPriority, "1"))
+	assert.True(t, child.IsSampled())
+	assert.True(t, root.IsSampled())
+	assert.True(t, root.Fork("", "sibling").IsSampled())
+	assert.Contains(t, child.(*Span).Tags(), TagString(TagSamplingPriority, HighestSamplingPriority))
+
+	sampled := newTracer(&Config{ServiceName: "test", Sampler: AlwaysSample()}).New("root")
+	sampled.SetTag(TagInt(TagSamplingPriority, 0))
+	assert.False(t, sampled.IsSampled())
+}
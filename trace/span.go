@@ -0,0 +1,235 @@
+package trace
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+var _ Trace = &Span{}
+
+// SpanLog is a log event recorded on span with timestamp.
+type SpanLog struct {
+	Timestamp time.Time
+	Fields    []LogField
+}
+
+// traceLocal is shared by the spans of same trace in current process.
+type traceLocal struct {
+	// mustKeep set by TagSamplingPriority on any span, keep the whole trace.
+	mustKeep atomic.Bool
+	// spans count of spans created, for MaxSpansPerTrace.
+	spans atomic.Int64
+}
+
+// Span is a trace span.
+type Span struct {
+	dapper        *dapper
+	local         *traceLocal
+	context       spanContext
+	operationName string
+	startTime     time.Time
+	duration      time.Duration
+	tags          []Tag
+	logs          []SpanLog
+	finished      bool
+	// overLimit span exceeds MaxSpansPerTrace, it is propagated but not reported.
+	overLimit bool
+}
+
+// ServiceName return the service name of tracer which created the span.
+func (s *Span) ServiceName() string {
+	return s.dapper.serviceName
+}
+
+// OperationName return the operation name of span.
+func (s *Span) OperationName() string {
+	return s.operationName
+}
+
+// TraceID return the trace id of span.
+func (s *Span) TraceID() string {
+	return s.context.traceIDString()
+}
+
+// SpanID return the span id of span.
+func (s *Span) SpanID() string {
+	return s.context.spanIDString()
+}
+
+// ParentID return the parent span id, empty for root span.
+func (s *Span) ParentID() string {
+	if s.context.ParentID == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%016x", s.context.ParentID)
+}
+
+// StartTime return the start time of span.
+func (s *Span) StartTime() time.Time {
+	return s.startTime
+}
+
+// Duration return the duration of span, zero before Finish.
+func (s *Span) Duration() time.Duration {
+	return s.duration
+}
+
+// Tags return the tags of span.
+func (s *Span) Tags() []Tag {
+	return s.tags
+}
+
+// Logs return the log events of span.
+func (s *Span) Logs() []SpanLog {
+	return s.logs
+}
+
+// IsSampled return true if the span will be reported.
+func (s *Span) IsSampled() bool {
+	return s.spanContext().isSampled()
+}
+
+// spanContext return span context with the must keep decision of trace.
+func (s *Span) spanContext() spanContext {
+	sc := s.context
+	if s.local.mustKeep.Load() {
+		sc.Flags |= flagSampled | flagMustKeep
+	}
+	return sc
+}
+
+// Fork fork a child span with same trace id, serviceName is recorded as peer.service if not empty.
+func (s *Span) Fork(serviceName, operationName string) Trace {
+	return s.child(serviceName, operationName).SetTag(SpanKindClientTag)
+}
+
+// Follow create a child span follows from current span.
+func (s *Span) Follow(serviceName, operationName string) Trace {
+	return s.child(serviceName, operationName).SetTag(TagString(TagSpanKind, "producer"))
+}
+
+func (s *Span) child(serviceName, operationName string) *Span {
+	sp := s.dapper.newSpanWithContext(operationName, s.context, s.local)
+	if serviceName != "" {
+		sp.setTag(TagString(TagPeerService, serviceName))
+	}
+	return sp
+}
+
+// Finish finish the span and report it, record err as error tag if not nil.
+// The span is handed to the reporter, so it ignores any change after finished.
+func (s *Span) Finish(perr *error) {
+	if s.finished {
+		return
+	}
+	s.duration = time.Since(s.startTime)
+	if perr != nil && *perr != nil {
+		err := *perr
+		s.setTag(TagBool(TagError, true))
+		s.setLog(Log(LogMessage, err.Error()))
+		if err, ok := err.(stackTracer); ok {
+			s.setLog(Log(LogStack, fmt.Sprintf("%+v", err.StackTrace())))
+		}
+	}
+	s.finished = true
+	s.dapper.report(s)
+}
+
+// SetTag set tags on span, overwrite the tag with same key.
+func (s *Span) SetTag(tags ...Tag) Trace {
+	if s.finished {
+		return s
+	}
+	for _, tag := range tags {
+		s.setTag(tag)
+	}
+	return s
+}
+
+func (s *Span) setTag(tag Tag) {
+	if s.dapper.validateTags {
+		if err := ValidateTag(tag); err != nil {
+			s.dapper.stdlog.Printf("span %s: %s", s.operationName, err)
+		}
+	}
+	if tag.Key == TagSamplingPriority {
+		tag = s.setSamplingPriority(tag)
+	}
+	if v, ok := tag.Value.(string); ok {
+		tag.Value = s.dapper.limits.truncate(v)
+	}
+	for i := range s.tags {
+		if s.tags[i].Key == tag.Key {
+			s.tags[i].Value = tag.Value
+			return
+		}
+	}
+	if s.dapper.limits.allowTag(len(s.tags)) {
+		s.tags = append(s.tags, tag)
+	}
+}
+
+// setSamplingPriority keep the whole trace if priority greater than 0,
+// drop the span if priority is 0 and the trace is not must keep.
+func (s *Span) setSamplingPriority(tag Tag) Tag {
+	priority, ok := tagInt(tag.Value)
+	if !ok {
+		return tag
+	}
+	if priority > 0 {
+		s.local.mustKeep.Store(true)
+		s.context.Flags |= flagSampled | flagMustKeep
+		return TagString(TagSamplingPriority, HighestSamplingPriority)
+	}
+	if !s.local.mustKeep.Load() {
+		s.context.Flags &^= flagSampled
+	}
+	return tag
+}
+
+// SetLog record a log event on span.
+func (s *Span) SetLog(logs ...LogField) Trace {
+	if !s.finished {
+		s.setLog(logs...)
+	}
+	return s
+}
+
+func (s *Span) setLog(logs ...LogField) {
+	if len(logs) == 0 || !s.dapper.limits.allowLog(len(s.logs)) {
+		return
+	}
+	fields := make([]LogField, len(logs))
+	for i, l := range logs {
+		fields[i] = LogField{Key: l.Key, Value: s.dapper.limits.truncate(l.Value)}
+	}
+	s.logs = append(s.logs, SpanLog{Timestamp: time.Now(), Fields: fields})
+}
+
+// SetBaggageItem set baggage item on span, inherited by spans forked after.
+func (s *Span) SetBaggageItem(key, val string) Trace {
+	if s.finished {
+		return s
+	}
+	s.context = s.context.withBaggageItem(key, val)
+	return s
+}
+
+// BaggageItem return the baggage value of key.
+func (s *Span) BaggageItem(key string) string {
+	return s.context.Baggage[key]
+}
+
+// SetTitle reset span operation name.
+func (s *Span) SetTitle(title string) {
+	if s.finished {
+		return
+	}
+	s.operationName = title
+}
+
+// String return span context string.
+func (s *Span) String() string {
+	return s.spanContext().String()
+}
@@ -1,5 +1,7 @@
 package trace
 
+import "strconv"
+
 // Standard Span tags https://github.com/opentracing/specification/blob/master/semantic_conventions.md#span-tags-table
 const (
 	// The software package, framework, library, or module that generated the associated Span.
@@ -194,3 +196,93 @@ var (
 	SpanKindClientTag = TagString(TagSpanKind, "client")
 	SpanKindServerTag = TagString(TagSpanKind, "server")
 )
+
+// Component new TagComponent tag.
+func Component(val string) Tag {
+	return TagString(TagComponent, val)
+}
+
+// DBInstance new TagDBInstance tag.
+func DBInstance(val string) Tag {
+	return TagString(TagDBInstance, val)
+}
+
+// DBStatement new TagDBStatement tag.
+func DBStatement(val string) Tag {
+	return TagString(TagDBStatement, val)
+}
+
+// DBType new TagDBType tag.
+func DBType(val string) Tag {
+	return TagString(TagDBType, val)
+}
+
+// DBUser new TagDBUser tag.
+func DBUser(val string) Tag {
+	return TagString(TagDBUser, val)
+}
+
+// Error new TagError tag.
+func Error(val bool) Tag {
+	return TagBool(TagError, val)
+}
+
+// HTTPMethod new TagHTTPMethod tag.
+func HTTPMethod(val string) Tag {
+	return TagString(TagHTTPMethod, val)
+}
+
+// HTTPStatusCode new TagHTTPStatusCode tag.
+func HTTPStatusCode(val int) Tag {
+	return TagInt(TagHTTPStatusCode, val)
+}
+
+// HTTPURL new TagHTTPURL tag.
+func HTTPURL(val string) Tag {
+	return TagString(TagHTTPURL, val)
+}
+
+// MessageBusDestination new TagMessageBusDestination tag.
+func MessageBusDestination(val string) Tag {
+	return TagString(TagMessageBusDestination, val)
+}
+
+// PeerAddress new TagPeerAddress tag.
+func PeerAddress(val string) Tag {
+	return TagString(TagPeerAddress, val)
+}
+
+// PeerHostname new TagPeerHostname tag.
+func PeerHostname(val string) Tag {
+	return TagString(TagPeerHostname, val)
+}
+
+// PeerIPv4 new TagPeerIPv4 tag.
+func PeerIPv4(val string) Tag {
+	return TagString(TagPeerIPv4, val)
+}
+
+// PeerIPv6 new TagPeerIPv6 tag.
+func PeerIPv6(val string) Tag {
+	return TagString(TagPeerIPv6, val)
+}
+
+// PeerPort new TagPeerPort tag.
+func PeerPort(val int) Tag {
+	return TagInt(TagPeerPort, val)
+}
+
+// PeerService new TagPeerService tag.
+func PeerService(val string) Tag {
+	return TagString(TagPeerService, val)
+}
+
+// SamplingPriority new TagSamplingPriority tag, greater than 0 keep the whole trace.
+func SamplingPriority(val int) Tag {
+	return TagString(TagSamplingPriority, strconv.Itoa(val))
+}
+
+// SpanKind new TagSpanKind tag, e.g. "client", "server", "producer", "consumer".
+func SpanKind(val string) Tag {
+	return TagString(TagSpanKind, val)
+}
@@ -0,0 +1,36 @@
+package trace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedTag(t *testing.T) {
+	assert.Equal(t, Tag{Key: TagHTTPStatusCode, Value: 200}, HTTPStatusCode(200))
+	assert.Equal(t, Tag{Key: TagPeerPort, Value: 80}, PeerPort(80))
+	assert.Equal(t, Tag{Key: TagError, Value: true}, Error(true))
+	assert.Equal(t, Tag{Key: TagSamplingPriority, Value: HighestSamplingPriority}, SamplingPriority(999))
+}
+
+func TestValidateTag(t *testing.T) {
+	for _, tag := range []Tag{
+		HTTPStatusCode(200),
+		TagInt64(TagPeerPort, 80),
+		DBStatement("SELECT 1"),
+		Error(false),
+		SamplingPriority(1),
+		TagFloat64("custom", 1.5),
+	} {
+		assert.NoError(t, ValidateTag(tag), tag.Key)
+	}
+	for _, tag := range []Tag{
+		TagString(TagHTTPStatusCode, "200"),
+		TagString(TagPeerPort, "80"),
+		TagString(TagError, "true"),
+		TagInt(TagDBStatement, 1),
+		TagFloat64(TagHTTPStatusCode, 200),
+	} {
+		assert.Error(t, ValidateTag(tag), tag.Key)
+	}
+}
@@ -0,0 +1,274 @@
+package trace
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// default tail sampling config.
+const (
+	_defaultTailWindow       = time.Second * 10
+	_defaultTailMaxSpans     = 100000
+	_defaultTailMaxDecisions = 100000
+)
+
+// TailSamplingConfig tail sampling config.
+type TailSamplingConfig struct {
+	// Window how long the finished spans of a trace are held before
+	// decision, counting from the first finished span, default 10s.
+	Window time.Duration
+	// LatencyThreshold keep the trace if any span takes longer, 0 meaning disabled.
+	LatencyThreshold time.Duration
+	// MaxSpans memory budget of held spans, the oldest trace is decided
+	// early when exceeded, default 100000.
+	MaxSpans int
+	// MaxDecisions memory budget of decisions remembered for late spans,
+	// the oldest decision is forgotten when exceeded, default 100000.
+	MaxDecisions int
+}
+
+func (c *TailSamplingConfig) fix() {
+	if c.Window <= 0 {
+		c.Window = _defaultTailWindow
+	}
+	if c.MaxSpans <= 0 {
+		c.MaxSpans = _defaultTailMaxSpans
+	}
+	if c.MaxDecisions <= 0 {
+		c.MaxDecisions = _defaultTailMaxDecisions
+	}
+}
+
+var _ Reporter = &TailSampler{}
+
+// tailTrace the held spans of a trace.
+type tailTrace struct {
+	traceID  string
+	deadline time.Time
+	spans    []*Span
+	keep     bool
+}
+
+// TailSampler hold finished spans of a trace for a window, then report
+// the whole trace to next reporter if any span is head sampled, has
+// error=true, takes longer than LatencyThreshold or carries
+// sampling.priority=999, drop it otherwise.
+type TailSampler struct {
+	cfg  TailSamplingConfig
+	next Reporter
+
+	mu      sync.Mutex
+	traces  map[string]*list.Element
+	order   *list.List
+	held    int
+	decided map[string]*list.Element
+	// decisions in decided order, which is also the expire order.
+	decisions *list.List
+	closed    bool
+
+	kept    atomic.Int64
+	dropped atomic.Int64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+type tailDecision struct {
+	traceID string
+	keep    bool
+	expire  time.Time
+}
+
+// NewTailSampler new a tail sampler reports kept traces to next.
+func NewTailSampler(cfg *TailSamplingConfig, next Reporter) *TailSampler {
+	c := TailSamplingConfig{}
+	if cfg != nil {
+		c = *cfg
+	}
+	c.fix()
+	t := &TailSampler{
+		cfg:       c,
+		next:      next,
+		traces:    make(map[string]*list.Element),
+		order:     list.New(),
+		decided:   make(map[string]*list.Element),
+		decisions: list.New(),
+		done:      make(chan struct{}),
+	}
+	t.wg.Add(1)
+	go t.daemon()
+	return t
+}
+
+// WriteSpan hold the span until the trace is decided.
+func (t *TailSampler) WriteSpan(sp *Span) error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return ErrReporterClosed
+	}
+	traceID := sp.TraceID()
+	// late span of a decided trace follows the decision.
+	if e, ok := t.decided[traceID]; ok {
+		keep := e.Value.(*tailDecision).keep
+		t.mu.Unlock()
+		return t.emit([]*Span{sp}, keep)
+	}
+	var tt *tailTrace
+	if e, ok := t.traces[traceID]; ok {
+		tt = e.Value.(*tailTrace)
+	} else {
+		tt = &tailTrace{traceID: traceID, deadline: time.Now().Add(t.cfg.Window)}
+		t.traces[traceID] = t.order.PushBack(tt)
+	}
+	tt.spans = append(tt.spans, sp)
+	tt.keep = tt.keep || t.shouldKeep(sp)
+	t.held++
+	// decide the oldest traces early when exceed memory budget.
+	var evicted []*tailTrace
+	for t.held > t.cfg.MaxSpans && t.order.Len() > 0 {
+		evicted = append(evicted, t.remove(t.order.Front()))
+	}
+	t.mu.Unlock()
+	return t.flush(evicted)
+}
+
+// shouldKeep return true if the span makes the whole trace kept.
+func (t *TailSampler) shouldKeep(sp *Span) bool {
+	if sp.IsSampled() {
+		return true
+	}
+	if t.cfg.LatencyThreshold > 0 && sp.Duration() > t.cfg.LatencyThreshold {
+		return true
+	}
+	for _, tag := range sp.Tags() {
+		switch tag.Key {
+		case TagError:
+			if v, ok := tag.Value.(bool); ok && v {
+				return true
+			}
+		case TagSamplingPriority:
+			if tagString(tag.Value) == HighestSamplingPriority {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// remove remove the trace from buffer and record the decision, must hold lock.
+func (t *TailSampler) remove(e *list.Element) *tailTrace {
+	tt := t.order.Remove(e).(*tailTrace)
+	delete(t.traces, tt.traceID)
+	t.held -= len(tt.spans)
+	d := &tailDecision{traceID: tt.traceID, keep: tt.keep, expire: time.Now().Add(t.cfg.Window)}
+	t.decided[tt.traceID] = t.decisions.PushBack(d)
+	// forget the oldest decisions when exceed memory budget.
+	for t.decisions.Len() > t.cfg.MaxDecisions {
+		t.forget(t.decisions.Front())
+	}
+	return tt
+}
+
+// forget remove the decision, must hold lock.
+func (t *TailSampler) forget(e *list.Element) {
+	delete(t.decided, t.decisions.Remove(e).(*tailDecision).traceID)
+}
+
+func (t *TailSampler) flush(tts []*tailTrace) (err error) {
+	for _, tt := range tts {
+		if tt.keep {
+			t.kept.Add(1)
+		} else {
+			t.dropped.Add(1)
+		}
+		if e := t.emit(tt.spans, tt.keep); e != nil {
+			err = e
+		}
+	}
+	return
+}
+
+func (t *TailSampler) emit(spans []*Span, keep bool) (err error) {
+	if !keep {
+		return nil
+	}
+	for _, sp := range spans {
+		if e := t.next.WriteSpan(sp); e != nil {
+			err = e
+		}
+	}
+	return
+}
+
+// expire decide the traces whose window ended.
+func (t *TailSampler) expire(now time.Time) {
+	var expired []*tailTrace
+	t.mu.Lock()
+	for e := t.order.Front(); e != nil; e = t.order.Front() {
+		if e.Value.(*tailTrace).deadline.After(now) {
+			break
+		}
+		expired = append(expired, t.remove(e))
+	}
+	for e := t.decisions.Front(); e != nil; e = t.decisions.Front() {
+		if !e.Value.(*tailDecision).expire.Before(now) {
+			break
+		}
+		t.forget(e)
+	}
+	t.mu.Unlock()
+	t.flush(expired)
+}
+
+func (t *TailSampler) daemon() {
+	defer t.wg.Done()
+	interval := t.cfg.Window / 4
+	if interval < time.Millisecond*10 {
+		interval = time.Millisecond * 10
+	}
+	tk := time.NewTicker(interval)
+	defer tk.Stop()
+	for {
+		select {
+		case now := <-tk.C:
+			t.expire(now)
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// Kept return the count of traces kept.
+func (t *TailSampler) Kept() int64 {
+	return t.kept.Load()
+}
+
+// Dropped return the count of traces dropped.
+func (t *TailSampler) Dropped() int64 {
+	return t.dropped.Load()
+}
+
+// Close decide all held traces and close next reporter.
+func (t *TailSampler) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	t.mu.Unlock()
+	close(t.done)
+	t.wg.Wait()
+
+	var rest []*tailTrace
+	t.mu.Lock()
+	for t.order.Len() > 0 {
+		rest = append(rest, t.remove(t.order.Front()))
+	}
+	t.mu.Unlock()
+	t.flush(rest)
+	return t.next.Close()
+}
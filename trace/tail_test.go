@@ -0,0 +1,89 @@
+package trace
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTailTestTracer(cfg *TailSamplingConfig) (*dapper, *mockSender) {
+	sender := &mockSender{}
+	return newTracer(&Config{
+		ServiceName:  "test",
+		Sampler:      NeverSample(),
+		Reporter:     reporterFunc(func(sp *Span) error { return sender.Send([]*Span{sp}) }),
+		TailSampling: cfg,
+	}), sender
+}
+
+func TestTailSamplerError(t *testing.T) {
+	tracer, sender := newTailTestTracer(&TailSamplingConfig{Window: time.Millisecond * 50})
+	// trace without error is dropped.
+	ok := tracer.New("ok")
+	ok.Fork("", "child").Finish(nil)
+	ok.Finish(nil)
+
+	failed := tracer.New("failed")
+	err := errors.New("boom")
+	failed.Fork("", "child").Finish(&err)
+	failed.Fork("", "child").Finish(nil)
+	assert.Equal(t, 0, sender.count())
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, 2, sender.count())
+	// late span follows the decision.
+	failed.Finish(nil)
+	assert.Equal(t, 3, sender.count())
+
+	ts := tracer.reporter.(*TailSampler)
+	assert.Equal(t, int64(1), ts.Kept())
+	assert.Equal(t, int64(1), ts.Dropped())
+}
+
+func TestTailSamplerLatencyAndPriority(t *testing.T) {
+	tracer, sender := newTailTestTracer(&TailSamplingConfig{Window: time.Hour, LatencyThreshold: time.Millisecond * 10})
+	slow := tracer.New("slow")
+	time.Sleep(time.Millisecond * 20)
+	slow.Finish(nil)
+
+	priority := tracer.New("priority").Fork("", "child")
+	priority.SetTag(TagString(TagSamplingPriority, HighestSamplingPriority))
+	priority.Finish(nil)
+
+	tracer.New("fast").Finish(nil)
+	assert.NoError(t, tracer.Close())
+	assert.Equal(t, 2, sender.count())
+}
+
+func TestTailSamplerBudget(t *testing.T) {
+	tracer, sender := newTailTestTracer(&TailSamplingConfig{Window: time.Hour, MaxSpans: 2})
+	err := errors.New("boom")
+	tracer.New("first").Finish(&err)
+	tracer.New("second").Finish(nil)
+	assert.Equal(t, 0, sender.count())
+	tracer.New("third").Finish(nil)
+	assert.Equal(t, 1, sender.count())
+	ts := tracer.reporter.(*TailSampler)
+	assert.Equal(t, int64(1), ts.Kept())
+}
+
+func TestTailSamplerDecisionBudget(t *testing.T) {
+	tracer, _ := newTailTestTracer(&TailSamplingConfig{Window: time.Hour, MaxSpans: 1, MaxDecisions: 2})
+	ts := tracer.reporter.(*TailSampler)
+	var roots []Trace
+	for i := 0; i < 10; i++ {
+		root := tracer.New("root")
+		root.Fork("", "child").Finish(nil)
+		roots = append(roots, root)
+	}
+	ts.mu.Lock()
+	assert.Len(t, ts.decided, 2)
+	assert.Equal(t, 2, ts.decisions.Len())
+	_, oldest := ts.decided[roots[0].TraceID()]
+	_, newest := ts.decided[roots[8].TraceID()]
+	ts.mu.Unlock()
+	assert.False(t, oldest)
+	assert.True(t, newest)
+	assert.Equal(t, int64(9), ts.Dropped())
+}
@@ -0,0 +1,119 @@
+package trace
+
+import "io"
+
+var (
+	// _tracer is the global tracer, use nooptracer before Init or SetGlobalTracer.
+	_tracer Tracer = nooptracer{}
+)
+
+// Trace trace common interface.
+type Trace interface {
+	// TraceID return the trace id of current trace, hex encoded.
+	TraceID() string
+
+	// SpanID return the span id of current span, hex encoded.
+	SpanID() string
+
+	// Fork fork a child trace with client trace.
+	Fork(serviceName, operationName string) Trace
+
+	// Follow create a child trace which follows from current trace,
+	// the parent does not wait for the child to finish.
+	Follow(serviceName, operationName string) Trace
+
+	// Finish when trace finish call it.
+	Finish(err *error)
+
+	// SetTag adds a tag to the trace.
+	//
+	// If there is a pre-existing tag set for `key`, it is overwritten.
+	//
+	// Tag values can be numeric types, strings, or bools. The behavior of
+	// other tag value types is undefined at the OpenTracing level.
+	SetTag(tags ...Tag) Trace
+
+	// SetLog record a log event with the given fields at current time.
+	SetLog(logs ...LogField) Trace
+
+	// IsSampled return true if the trace will be reported.
+	IsSampled() bool
+
+	// SetBaggageItem sets a key:value pair on this trace and its local
+	// descendants, and propagates it to downstream processes.
+	SetBaggageItem(key, val string) Trace
+
+	// BaggageItem return the value of baggage key, empty if not exists.
+	BaggageItem(key string) string
+
+	// SetTitle reset trace title
+	SetTitle(title string)
+
+	// String return trace id with span id and parent id, only for debug.
+	String() string
+}
+
+// Tracer is a simple, thin interface for Trace creation and propagation.
+type Tracer interface {
+	// New trace instance with given operationName.
+	New(operationName string, opts ...Option) Trace
+	// Inject takes the Trace instance and injects it for
+	// propagation within `carrier`. The actual type of `carrier` depends on
+	// the value of `format`.
+	Inject(t Trace, format interface{}, carrier interface{}) error
+	// Extract returns a Trace instance given `format` and `carrier`.
+	// return `ErrTraceNotFound` if trace not found.
+	Extract(format interface{}, carrier interface{}) (Trace, error)
+}
+
+// SetGlobalTracer SetGlobalTracer
+func SetGlobalTracer(tracer Tracer) {
+	_tracer = tracer
+}
+
+// GlobalTracer return the global tracer.
+func GlobalTracer() Tracer {
+	return _tracer
+}
+
+// Option dapper Option
+type Option func(*option)
+
+type option struct {
+	Debug bool
+}
+
+var defaultOption = option{}
+
+// EnableDebug enable debug mode, the trace will always be sampled.
+func EnableDebug() Option {
+	return func(opt *option) {
+		opt.Debug = true
+	}
+}
+
+// New trace instance with given operationName.
+func New(operationName string, opts ...Option) Trace {
+	return _tracer.New(operationName, opts...)
+}
+
+// Inject takes the Trace instance and injects it for
+// propagation within `carrier`. The actual type of `carrier` depends on
+// the value of `format`.
+func Inject(t Trace, format interface{}, carrier interface{}) error {
+	return _tracer.Inject(t, format, carrier)
+}
+
+// Extract returns a Trace instance given `format` and `carrier`.
+// return `ErrTraceNotFound` if trace not found.
+func Extract(format interface{}, carrier interface{}) (Trace, error) {
+	return _tracer.Extract(format, carrier)
+}
+
+// Close close the global tracer, flush the spans in reporter.
+func Close() error {
+	if closer, ok := _tracer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
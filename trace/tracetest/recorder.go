@@ -0,0 +1,111 @@
+// Package tracetest provides an in-memory span recorder for unit tests.
+package tracetest
+
+import (
+	"MagicWand/trace"
+	"sync"
+	"testing"
+)
+
+var _ trace.Reporter = &Recorder{}
+
+// Recorder collects finished spans in memory.
+type Recorder struct {
+	mu    sync.Mutex
+	spans []*trace.Span
+}
+
+// NewRecorder new a span recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Install set a global tracer which samples every trace and reports spans
+// to the returned recorder, the previous global tracer is restored on test cleanup.
+func Install(tb testing.TB) *Recorder {
+	r := NewRecorder()
+	old := trace.GlobalTracer()
+	trace.Init(&trace.Config{ServiceName: tb.Name(), DisableSample: true, Reporter: r})
+	tb.Cleanup(func() {
+		trace.SetGlobalTracer(old)
+	})
+	return r
+}
+
+// WriteSpan record span.
+func (r *Recorder) WriteSpan(sp *trace.Span) error {
+	r.mu.Lock()
+	r.spans = append(r.spans, sp)
+	r.mu.Unlock()
+	return nil
+}
+
+// Close do nothing.
+func (r *Recorder) Close() error {
+	return nil
+}
+
+// Spans return the finished spans in finish order.
+func (r *Recorder) Spans() []*trace.Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*trace.Span(nil), r.spans...)
+}
+
+// Reset drop all recorded spans.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.spans = nil
+	r.mu.Unlock()
+}
+
+// FindSpans return the finished spans with operationName.
+func (r *Recorder) FindSpans(operationName string) (spans []*trace.Span) {
+	for _, sp := range r.Spans() {
+		if sp.OperationName() == operationName {
+			spans = append(spans, sp)
+		}
+	}
+	return
+}
+
+// FindSpan return the first finished span with operationName.
+func (r *Recorder) FindSpan(operationName string) (*trace.Span, bool) {
+	if spans := r.FindSpans(operationName); len(spans) > 0 {
+		return spans[0], true
+	}
+	return nil, false
+}
+
+// IsChildOf return true if child is a direct child span of parent.
+func IsChildOf(child *trace.Span, parent trace.Trace) bool {
+	return child.TraceID() == parent.TraceID() && child.ParentID() == parent.SpanID()
+}
+
+// TagValue return the value of tag key on span.
+func TagValue(sp *trace.Span, key string) (interface{}, bool) {
+	for _, tag := range sp.Tags() {
+		if tag.Key == key {
+			return tag.Value, true
+		}
+	}
+	return nil, false
+}
+
+// HasTag return true if span has tag key with value.
+func HasTag(sp *trace.Span, key string, value interface{}) bool {
+	v, ok := TagValue(sp, key)
+	return ok && v == value
+}
+
+// LogEvents return the values of LogEvent field logged on span in order.
+func LogEvents(sp *trace.Span) (events []string) {
+	for _, l := range sp.Logs() {
+		for _, f := range l.Fields {
+			if f.Key == trace.LogEvent {
+				events = append(events, f.Value)
+			}
+		}
+	}
+	return
+}
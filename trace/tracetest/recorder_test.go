@@ -0,0 +1,58 @@
+package tracetest
+
+import (
+	"MagicWand/trace"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstall(t *testing.T) {
+	old := trace.GlobalTracer()
+	var r *Recorder
+	t.Run("install", func(t *testing.T) {
+		r = Install(t)
+		assert.NotEqual(t, old, trace.GlobalTracer())
+		trace.New("root").Finish(nil)
+		assert.Len(t, r.Spans(), 1)
+	})
+	// the previous tracer is restored on cleanup.
+	assert.Equal(t, old, trace.GlobalTracer())
+	trace.New("after").Finish(nil)
+	assert.Len(t, r.Spans(), 1)
+}
+
+func TestRecorder(t *testing.T) {
+	r := Install(t)
+	root := trace.New("root")
+	child := root.Fork("", "child")
+	child.SetTag(trace.TagString(trace.TagComponent, "db")).SetLog(trace.Log(trace.LogEvent, "query"))
+	child.Finish(nil)
+	other := root.Fork("", "child")
+	other.Finish(nil)
+	root.Finish(nil)
+
+	spans := r.Spans()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, []string{child.SpanID(), other.SpanID(), root.SpanID()},
+		[]string{spans[0].SpanID(), spans[1].SpanID(), spans[2].SpanID()})
+
+	children := r.FindSpans("child")
+	assert.Len(t, children, 2)
+	sp, ok := r.FindSpan("child")
+	assert.True(t, ok)
+	assert.Equal(t, child.SpanID(), sp.SpanID())
+	assert.True(t, IsChildOf(sp, root))
+	assert.False(t, IsChildOf(spans[2], root))
+	assert.True(t, HasTag(sp, trace.TagComponent, "db"))
+	v, ok := TagValue(sp, trace.TagComponent)
+	assert.True(t, ok)
+	assert.Equal(t, "db", v)
+	assert.Equal(t, []string{"query"}, LogEvents(sp))
+	_, ok = r.FindSpan("missing")
+	assert.False(t, ok)
+
+	r.Reset()
+	assert.Empty(t, r.Spans())
+	assert.Empty(t, r.FindSpans("child"))
+}
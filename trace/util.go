@@ -0,0 +1,20 @@
+package trace
+
+import (
+	"math/rand/v2"
+
+	"github.com/pkg/errors"
+)
+
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+// genID generate a non-zero random 64 bits id.
+func genID() uint64 {
+	for {
+		if id := rand.Uint64(); id != 0 {
+			return id
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package trace
+
+import (
+	"fmt"
+)
+
+// tagType the value type convention of standard tag.
+type tagType int
+
+const (
+	tagTypeString tagType = iota
+	tagTypeInteger
+	tagTypeBool
+)
+
+func (t tagType) String() string {
+	switch t {
+	case tagTypeInteger:
+		return "integer"
+	case tagTypeBool:
+		return "bool"
+	}
+	return "string"
+}
+
+// _tagTypes the value type of standard tags documented in tag.go.
+var _tagTypes = map[string]tagType{
+	TagComponent:             tagTypeString,
+	TagDBInstance:            tagTypeString,
+	TagDBStatement:           tagTypeString,
+	TagDBType:                tagTypeString,
+	TagDBUser:                tagTypeString,
+	TagError:                 tagTypeBool,
+	TagHTTPMethod:            tagTypeString,
+	TagHTTPStatusCode:        tagTypeInteger,
+	TagHTTPURL:               tagTypeString,
+	TagMessageBusDestination: tagTypeString,
+	TagPeerAddress:           tagTypeString,
+	TagPeerHostname:          tagTypeString,
+	TagPeerIPv4:              tagTypeString,
+	TagPeerIPv6:              tagTypeString,
+	TagPeerPort:              tagTypeInteger,
+	TagPeerService:           tagTypeString,
+	TagSamplingPriority:      tagTypeString,
+	TagSpanKind:              tagTypeString,
+}
+
+// ValidateTag return error if the value type of a standard tag doesn't
+// match the convention, tags not defined in tag.go are always valid.
+func ValidateTag(tag Tag) error {
+	expect, ok := _tagTypes[tag.Key]
+	if !ok {
+		return nil
+	}
+	var valid bool
+	switch tag.Value.(type) {
+	case string:
+		valid = expect == tagTypeString
+	case bool:
+		valid = expect == tagTypeBool
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		valid = expect == tagTypeInteger
+	}
+	if !valid {
+		return fmt.Errorf("trace: tag %s expect %s value, got %T(%v)", tag.Key, expect, tag.Value, tag.Value)
+	}
+	return nil
+}
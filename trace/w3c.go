@@ -0,0 +1,108 @@
+package trace
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// W3C trace context headers, see https://www.w3.org/TR/trace-context/
+const (
+	W3CTraceParent = "traceparent"
+	W3CTraceState  = "tracestate"
+
+	// _w3cVendorKey is the tracestate list member owned by us, it carries
+	// TagSamplingPriority so a must keep decision survives the hop.
+	_w3cVendorKey = "magicwand"
+	// _w3cMaxMembers is the max list members of tracestate.
+	_w3cMaxMembers = 32
+
+	_w3cVersion      = "00"
+	_w3cFlagsSampled = 0x01
+)
+
+var _ propagator = w3cPropagator{}
+
+type w3cPropagator struct{}
+
+// Inject write traceparent and tracestate to carrier.
+func (w3cPropagator) Inject(sc spanContext, carrier Carrier) {
+	var flags byte
+	if sc.isSampled() {
+		flags |= _w3cFlagsSampled
+	}
+	carrier.Set(W3CTraceParent, fmt.Sprintf("%s-%016x%016x-%016x-%02x", _w3cVersion, sc.TraceIDHigh, sc.TraceID, sc.SpanID, flags))
+	var members []string
+	if sc.isMustKeep() {
+		members = append(members, _w3cVendorKey+"=p:"+HighestSamplingPriority)
+	}
+	for _, member := range splitTraceState(sc.TraceState) {
+		if len(members) >= _w3cMaxMembers {
+			break
+		}
+		if traceStateKey(member) != _w3cVendorKey {
+			members = append(members, member)
+		}
+	}
+	if len(members) > 0 {
+		carrier.Set(W3CTraceState, strings.Join(members, ","))
+	}
+}
+
+// Extract read traceparent and tracestate from carrier.
+func (w3cPropagator) Extract(carrier Carrier) (sc spanContext, err error) {
+	tp := strings.TrimSpace(carrier.Get(W3CTraceParent))
+	if tp == "" {
+		return sc, ErrTraceNotFound
+	}
+	parts := strings.Split(tp, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, ErrTraceCorrupted
+	}
+	// version 00 must have exactly 4 parts, future version may append fields.
+	if parts[0] == _w3cVersion && len(parts) != 4 {
+		return sc, ErrTraceCorrupted
+	}
+	if sc.TraceIDHigh, sc.TraceID, err = parseTraceID(parts[1]); err != nil {
+		return sc, ErrTraceCorrupted
+	}
+	if sc.SpanID, err = parseSpanID(parts[2]); err != nil {
+		return sc, ErrTraceCorrupted
+	}
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return sc, ErrTraceCorrupted
+	}
+	if !sc.IsValid() {
+		return sc, ErrTraceCorrupted
+	}
+	if flags&_w3cFlagsSampled != 0 {
+		sc.Flags |= flagSampled
+	}
+	sc.TraceState = carrier.Get(W3CTraceState)
+	for _, member := range splitTraceState(sc.TraceState) {
+		if traceStateKey(member) != _w3cVendorKey {
+			continue
+		}
+		if p, err := strconv.Atoi(strings.TrimPrefix(member[len(_w3cVendorKey)+1:], "p:")); err == nil && p > 0 {
+			sc.Flags |= flagSampled | flagMustKeep
+		}
+	}
+	return sc, nil
+}
+
+func splitTraceState(ts string) (members []string) {
+	for _, member := range strings.Split(ts, ",") {
+		if member = strings.TrimSpace(member); member != "" {
+			members = append(members, member)
+		}
+	}
+	return
+}
+
+func traceStateKey(member string) string {
+	if idx := strings.IndexByte(member, '='); idx > 0 {
+		return member[:idx]
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var _ Encoder = zipkinEncoder{}
+
+// ZipkinEncoder encode spans to zipkin v2 json, see https://zipkin.io/zipkin-api/#/default/post_spans
+var ZipkinEncoder Encoder = zipkinEncoder{}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName,omitempty"`
+	IPv4        string `json:"ipv4,omitempty"`
+	IPv6        string `json:"ipv6,omitempty"`
+	Port        int    `json:"port,omitempty"`
+}
+
+type zipkinAnnotation struct {
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+type zipkinSpan struct {
+	TraceID        string             `json:"traceId"`
+	ID             string             `json:"id"`
+	ParentID       string             `json:"parentId,omitempty"`
+	Name           string             `json:"name"`
+	Kind           string             `json:"kind,omitempty"`
+	Timestamp      int64              `json:"timestamp"`
+	Duration       int64              `json:"duration"`
+	Debug          bool               `json:"debug,omitempty"`
+	LocalEndpoint  *zipkinEndpoint    `json:"localEndpoint,omitempty"`
+	RemoteEndpoint *zipkinEndpoint    `json:"remoteEndpoint,omitempty"`
+	Annotations    []zipkinAnnotation `json:"annotations,omitempty"`
+	Tags           map[string]string  `json:"tags,omitempty"`
+}
+
+type zipkinEncoder struct{}
+
+func (zipkinEncoder) ContentType() string {
+	return "application/json"
+}
+
+func (zipkinEncoder) Encode(spans []*Span) ([]byte, error) {
+	zs := make([]*zipkinSpan, 0, len(spans))
+	for _, sp := range spans {
+		zs = append(zs, toZipkinSpan(sp))
+	}
+	return json.Marshal(zs)
+}
+
+func toZipkinSpan(sp *Span) *zipkinSpan {
+	z := &zipkinSpan{
+		TraceID:       sp.TraceID(),
+		ID:            sp.SpanID(),
+		ParentID:      sp.ParentID(),
+		Name:          sp.OperationName(),
+		Timestamp:     sp.StartTime().UnixMicro(),
+		Duration:      sp.Duration().Microseconds(),
+		Debug:         sp.context.isDebug(),
+		LocalEndpoint: &zipkinEndpoint{ServiceName: sp.ServiceName()},
+	}
+	remote := &zipkinEndpoint{}
+	for _, tag := range sp.Tags() {
+		switch tag.Key {
+		case TagSpanKind:
+			if kind, ok := zipkinKind(tag.Value); ok {
+				z.Kind = kind
+				continue
+			}
+		case TagPeerService:
+			remote.ServiceName = tagString(tag.Value)
+			continue
+		case TagPeerIPv4:
+			remote.IPv4 = tagString(tag.Value)
+			continue
+		case TagPeerIPv6:
+			remote.IPv6 = tagString(tag.Value)
+			continue
+		case TagPeerPort:
+			if port, ok := tagInt(tag.Value); ok {
+				remote.Port = int(port)
+				continue
+			}
+		}
+		if z.Tags == nil {
+			z.Tags = make(map[string]string, len(sp.Tags()))
+		}
+		z.Tags[tag.Key] = tagString(tag.Value)
+	}
+	if *remote != (zipkinEndpoint{}) {
+		z.RemoteEndpoint = remote
+	}
+	for _, l := range sp.Logs() {
+		kvs := make([]string, 0, len(l.Fields))
+		for _, f := range l.Fields {
+			kvs = append(kvs, f.Key+"="+f.Value)
+		}
+		z.Annotations = append(z.Annotations, zipkinAnnotation{Timestamp: l.Timestamp.UnixMicro(), Value: strings.Join(kvs, " ")})
+	}
+	return z
+}
+
+func zipkinKind(v interface{}) (string, bool) {
+	switch tagString(v) {
+	case "client":
+		return "CLIENT", true
+	case "server":
+		return "SERVER", true
+	case "producer":
+		return "PRODUCER", true
+	case "consumer":
+		return "CONSUMER", true
+	}
+	return "", false
+}
+
+// tagString format tag value as string.
+func tagString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return fmt.Sprint(v)
+}
+
+// tagInt convert integer or numeric string tag value to int64.
+func tagInt(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	case string:
+		i, err := strconv.ParseInt(v, 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}